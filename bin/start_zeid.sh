@@ -1,7 +1,9 @@
 #!/bin/sh
 
-API_KEY=$(snapctl get api-key)
-API_SECRET=$(snapctl get api-secret)
-SERIAL_NUMBER=$(snapctl get serial-number)
+# the credentials are passed in the environment so they do not show up in
+# the command line of zeid.
+export ZEI_API_KEY=$(snapctl get api-key)
+export ZEI_API_SECRET=$(snapctl get api-secret)
+export ZEI_SERIAL_NUMBER=$(snapctl get serial-number)
 
-exec $SNAP/bin/zeid -api-key $API_KEY -api-secret $API_SECRET -serial-number $SERIAL_NUMBER
+exec $SNAP/bin/zeid
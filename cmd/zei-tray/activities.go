@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/getlantern/systray"
+)
+
+const (
+	// systray items can't be removed, a fixed number of items is added and
+	// hidden when there are fewer activities.
+	maxActivityItems = 24
+
+	activitiesRefreshInterval = 30 * time.Second
+)
+
+// activityMenu lists the activities to switch tracking to, and to assign to
+// the current side of the device.
+type activityMenu struct {
+	client zeid.Zei
+
+	mu         sync.Mutex
+	available  bool
+	activities []*zeid.Activity
+	currentID  string
+	assignOpen bool
+
+	switchItems []*systray.MenuItem
+	stopItem    *systray.MenuItem
+	assignItem  *systray.MenuItem
+	assignItems []*systray.MenuItem
+}
+
+func newActivityMenu(client zeid.Zei) *activityMenu {
+	m := &activityMenu{client: client}
+
+	for i := 0; i < maxActivityItems; i++ {
+		item := systray.AddMenuItem("", "Start tracking this activity")
+		item.Hide()
+
+		m.switchItems = append(m.switchItems, item)
+		go m.onClick(item, i, m.start)
+	}
+
+	m.stopItem = systray.AddMenuItem("Stop", "Stop tracking the current activity")
+	go m.onClick(m.stopItem, 0, func(int) { m.stop() })
+
+	systray.AddSeparator()
+
+	m.assignItem = systray.AddMenuItem("Assign to current side ▸", "Assign an activity to the side on top of the device")
+	go m.onClick(m.assignItem, 0, func(int) { m.toggleAssign() })
+
+	for i := 0; i < maxActivityItems; i++ {
+		item := systray.AddMenuItem("", "Assign this activity to the current side")
+		item.Hide()
+
+		m.assignItems = append(m.assignItems, item)
+		go m.onClick(item, i, m.assign)
+	}
+
+	return m
+}
+
+func (m *activityMenu) onClick(item *systray.MenuItem, i int, f func(i int)) {
+	for range item.ClickedCh {
+		f(i)
+	}
+}
+
+// watch refreshes the activities periodically, they may be changed from
+// other clients.
+func (m *activityMenu) watch(ctx context.Context) {
+	for range time.Tick(activitiesRefreshInterval) {
+		m.mu.Lock()
+		available := m.available
+		m.mu.Unlock()
+
+		if available {
+			m.refresh(ctx)
+		}
+	}
+}
+
+// setAvailable shows the activities while zeid is available, and refreshes
+// them once it becomes available again.
+func (m *activityMenu) setAvailable(ctx context.Context, available bool) {
+	m.mu.Lock()
+	changed := available != m.available
+	m.available = available
+
+	if !available {
+		for i := range m.switchItems {
+			m.switchItems[i].Hide()
+			m.assignItems[i].Hide()
+		}
+		m.stopItem.Hide()
+		m.assignItem.Hide()
+	} else if changed {
+		m.stopItem.Show()
+		m.assignItem.Show()
+	}
+	m.mu.Unlock()
+
+	if available && changed {
+		m.refresh(ctx)
+	}
+}
+
+func (m *activityMenu) refresh(ctx context.Context) {
+	res, err := m.client.ListActivities(ctx, &zeid.ListActivitiesReq{Device: *device})
+	if err != nil {
+		log.Printf("failed to list activities: %+v", err)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.activities = res.Activities
+	m.currentID = res.CurrentActivityId
+	m.update()
+}
+
+// setCurrent marks the activity currently tracked.
+func (m *activityMenu) setCurrent(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if id == m.currentID {
+		return
+	}
+
+	m.currentID = id
+	m.update()
+}
+
+// update shows the activities, unless zeid is unavailable.
+func (m *activityMenu) update() {
+	if !m.available {
+		return
+	}
+
+	if len(m.activities) > maxActivityItems {
+		log.Printf("only %d out of %d activities are listed", maxActivityItems, len(m.activities))
+	}
+
+	for i := range m.switchItems {
+		if i >= len(m.activities) {
+			m.switchItems[i].Hide()
+			m.assignItems[i].Hide()
+			continue
+		}
+
+		a := m.activities[i]
+
+		m.switchItems[i].SetTitle(formatActivity(a))
+		if a.Id == m.currentID {
+			m.switchItems[i].Check()
+		} else {
+			m.switchItems[i].Uncheck()
+		}
+		m.switchItems[i].Show()
+
+		m.assignItems[i].SetTitle(fmt.Sprintf("    %s", a.Name))
+		if m.assignOpen {
+			m.assignItems[i].Show()
+		} else {
+			m.assignItems[i].Hide()
+		}
+	}
+}
+
+func (m *activityMenu) activity(i int) *zeid.Activity {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if i >= len(m.activities) {
+		return nil
+	}
+
+	return m.activities[i]
+}
+
+func (m *activityMenu) start(i int) {
+	a := m.activity(i)
+	if a == nil {
+		return
+	}
+
+	_, err := m.client.StartActivity(context.Background(), &zeid.StartActivityReq{
+		Device:     *device,
+		ActivityId: a.Id,
+	})
+	if err != nil {
+		log.Printf("failed to start activity: %+v", err)
+		return
+	}
+
+	m.setCurrent(a.Id)
+}
+
+func (m *activityMenu) stop() {
+	_, err := m.client.StopActivity(context.Background(), &zeid.StopActivityReq{Device: *device})
+	if err != nil {
+		log.Printf("failed to stop activity: %+v", err)
+		return
+	}
+
+	m.setCurrent("")
+}
+
+func (m *activityMenu) toggleAssign() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.assignOpen = !m.assignOpen
+	if m.assignOpen {
+		m.assignItem.SetTitle("Assign to current side ▾")
+	} else {
+		m.assignItem.SetTitle("Assign to current side ▸")
+	}
+
+	m.update()
+}
+
+func (m *activityMenu) assign(i int) {
+	a := m.activity(i)
+	if a == nil {
+		return
+	}
+
+	ctx := context.Background()
+
+	_, err := m.client.AssignActivity(ctx, &zeid.AssignActivityReq{
+		Device:     *device,
+		ActivityId: a.Id,
+	})
+	if err != nil {
+		log.Printf("failed to assign activity: %+v", err)
+		return
+	}
+
+	m.toggleAssign()
+	m.refresh(ctx)
+}
+
+func formatActivity(a *zeid.Activity) string {
+	if a.DeviceSide == 0 {
+		return a.Name
+	}
+
+	return fmt.Sprintf("%s (side %d)", a.Name, a.DeviceSide)
+}
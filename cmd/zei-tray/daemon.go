@@ -0,0 +1,38 @@
+package main
+
+import (
+	"log"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/getlantern/systray"
+)
+
+// maxReconnectDelay is the longest delay between two attempts to reach zeid.
+const maxReconnectDelay = 30 * time.Second
+
+// reconnectDelay doubles the delay before the next attempt to reach zeid.
+func reconnectDelay(delay time.Duration) time.Duration {
+	delay *= 2
+	if delay > maxReconnectDelay {
+		return maxReconnectDelay
+	}
+
+	return delay
+}
+
+// startDaemon starts zeid with its systemd user unit.
+func startDaemon(item *systray.MenuItem) {
+	out, err := exec.Command("systemctl", "--user", "start", *zeidUnit).CombinedOutput()
+	if err != nil {
+		log.Printf("failed to start zeid: %+v: %s", err, out)
+
+		item.SetTitle("Start zeid (failed)")
+		item.SetTooltip(strings.TrimSpace(string(out)))
+		return
+	}
+
+	item.SetTitle("Start zeid")
+	item.SetTooltip("")
+}
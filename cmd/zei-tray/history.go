@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/getlantern/systray"
+	"github.com/golang/protobuf/ptypes"
+	durpb "github.com/golang/protobuf/ptypes/duration"
+)
+
+const (
+	maxTotalItems  = 8
+	maxRecentItems = 5
+
+	historyRefreshInterval = time.Minute
+)
+
+// historyMenu shows the time tracked today, per activity, and the last
+// entries which can be resumed.
+type historyMenu struct {
+	client zeid.Zei
+
+	mu         sync.Mutex
+	available  bool
+	history    *zeid.HistoryResp
+	recentOpen bool
+
+	todayItem   *systray.MenuItem
+	totalItems  []*systray.MenuItem
+	recentItem  *systray.MenuItem
+	recentItems []*systray.MenuItem
+}
+
+func newHistoryMenu(client zeid.Zei) *historyMenu {
+	m := &historyMenu{client: client}
+
+	m.todayItem = systray.AddMenuItem("Today", "Time tracked today")
+	m.todayItem.Disable()
+
+	for i := 0; i < maxTotalItems; i++ {
+		item := systray.AddMenuItem("", "")
+		item.Disable()
+		item.Hide()
+
+		m.totalItems = append(m.totalItems, item)
+	}
+
+	m.recentItem = systray.AddMenuItem("Recent entries ▸", "Resume one of the last entries")
+	go func() {
+		for range m.recentItem.ClickedCh {
+			m.toggleRecent()
+		}
+	}()
+
+	for i := 0; i < maxRecentItems; i++ {
+		item := systray.AddMenuItem("", "Resume this activity")
+		item.Hide()
+
+		m.recentItems = append(m.recentItems, item)
+		go func(i int) {
+			for range item.ClickedCh {
+				m.resume(i)
+			}
+		}(i)
+	}
+
+	return m
+}
+
+// watch refreshes the history periodically.
+func (m *historyMenu) watch(ctx context.Context) {
+	for range time.Tick(historyRefreshInterval) {
+		m.mu.Lock()
+		available := m.available
+		m.mu.Unlock()
+
+		if available {
+			m.refresh(ctx)
+		}
+	}
+}
+
+// setAvailable shows the history while zeid is available, and refreshes it
+// once it becomes available again.
+func (m *historyMenu) setAvailable(ctx context.Context, available bool) {
+	m.mu.Lock()
+	changed := available != m.available
+	m.available = available
+
+	if !available {
+		m.todayItem.Hide()
+		m.recentItem.Hide()
+		for _, item := range m.totalItems {
+			item.Hide()
+		}
+		for _, item := range m.recentItems {
+			item.Hide()
+		}
+	} else if changed {
+		m.todayItem.Show()
+		m.recentItem.Show()
+	}
+	m.mu.Unlock()
+
+	if available && changed {
+		m.refresh(ctx)
+	}
+}
+
+func (m *historyMenu) refresh(ctx context.Context) {
+	y, mo, d := clk.Now().In(location).Date()
+
+	from, err := ptypes.TimestampProto(time.Date(y, mo, d, 0, 0, 0, 0, location))
+	if err != nil {
+		log.Printf("failed to convert start of day: %+v", err)
+		return
+	}
+
+	res, err := m.client.History(ctx, &zeid.HistoryReq{Device: *device, From: from})
+	if err != nil {
+		log.Printf("failed to get history: %+v", err)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.history = res
+	m.update()
+}
+
+// update shows the history, unless zeid is unavailable.
+func (m *historyMenu) update() {
+	if !m.available || m.history == nil {
+		return
+	}
+
+	m.todayItem.SetTitle(fmt.Sprintf("Today: %s", formatDuration(m.history.Total)))
+
+	for i, item := range m.totalItems {
+		if i >= len(m.history.Totals) {
+			item.Hide()
+			continue
+		}
+
+		t := m.history.Totals[i]
+		item.SetTitle(fmt.Sprintf("    %s - %s", t.Activity.GetName(), formatDuration(t.Duration)))
+		item.Show()
+	}
+
+	for i, item := range m.recentItems {
+		if i >= len(m.history.Entries) {
+			item.Hide()
+			continue
+		}
+
+		item.SetTitle(fmt.Sprintf("    %s", formatEntry(m.history.Entries[i])))
+		if m.recentOpen {
+			item.Show()
+		} else {
+			item.Hide()
+		}
+	}
+}
+
+func (m *historyMenu) toggleRecent() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.recentOpen = !m.recentOpen
+	if m.recentOpen {
+		m.recentItem.SetTitle("Recent entries ▾")
+	} else {
+		m.recentItem.SetTitle("Recent entries ▸")
+	}
+
+	m.update()
+}
+
+func (m *historyMenu) resume(i int) {
+	m.mu.Lock()
+	var activityID string
+	if m.history != nil && i < len(m.history.Entries) {
+		activityID = m.history.Entries[i].Activity.GetId()
+	}
+	m.mu.Unlock()
+
+	if activityID == "" {
+		return
+	}
+
+	ctx := context.Background()
+
+	_, err := m.client.StartActivity(ctx, &zeid.StartActivityReq{
+		Device:     *device,
+		ActivityId: activityID,
+	})
+	if err != nil {
+		log.Printf("failed to resume activity: %+v", err)
+		return
+	}
+
+	m.refresh(ctx)
+}
+
+func formatDuration(pb *durpb.Duration) string {
+	d, err := ptypes.Duration(pb)
+	if err != nil {
+		return "-"
+	}
+
+	return d.Truncate(time.Minute).String()
+}
+
+func formatEntry(e *zeid.TimeEntry) string {
+	startedAt, err := ptypes.Timestamp(e.StartedAt)
+	if err != nil {
+		return e.Activity.GetName()
+	}
+
+	if e.StoppedAt == nil {
+		return fmt.Sprintf("%s %s - now", e.Activity.GetName(), startedAt.In(location).Format("15:04"))
+	}
+
+	stoppedAt, err := ptypes.Timestamp(e.StoppedAt)
+	if err != nil {
+		return e.Activity.GetName()
+	}
+
+	return fmt.Sprintf(
+		"%s %s - %s",
+		e.Activity.GetName(),
+		startedAt.In(location).Format("15:04"),
+		stoppedAt.In(location).Format("15:04"),
+	)
+}
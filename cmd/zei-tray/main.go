@@ -5,21 +5,54 @@ import (
 	"flag"
 	"fmt"
 	"log"
-	"net/http"
 	"time"
 
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/zeidapi"
 	"github.com/pauldub/zei/rpc/zeid"
 	"github.com/getlantern/systray"
+	"github.com/golang/protobuf/ptypes"
 )
 
 var (
-	apiAddress = flag.String("api-addr", "http://localhost:8594", "zeid API address (default: 'http://localhost:8594')")
+	apiAddress = flag.String("api-addr", zeidapi.DefaultAddress(), "zeid API address, an HTTP URL or a unix:// socket path (default: the socket of zeid)")
+	tokenFile  = flag.String("token-file", zeidapi.DefaultTokenPath(), "File holding the token of the zeid API")
+	tlsCA      = flag.String("tls-ca", "", "PEM CA verifying the certificate of an https zeid API, instead of the system CAs (optional)")
+	tlsCert    = flag.String("tls-cert", "", "PEM client certificate presented to an https zeid API (optional)")
+	tlsKey     = flag.String("tls-key", "", "PEM key of the client certificate (optional)")
+	timezone   = flag.String("timezone", "Local", "Time zone used to display times, e.g. Europe/Paris (default: local time zone)")
+	device     = flag.String("device", "", "Serial number of the device, may be omitted while a single device is connected (optional)")
+	zeidUnit   = flag.String("zeid-unit", "zeid.service", "systemd user unit started by the 'Start zeid' action (default: 'zeid.service')")
+
+	clk      = clock.Real
+	location = time.Local
 )
 
 func main() {
 	flag.Parse()
 
-	client := zeid.NewZeiProtobufClient(*apiAddress, &http.Client{})
+	var err error
+	location, err = time.LoadLocation(*timezone)
+	if err != nil {
+		log.Fatalf("failed to load time zone: %+v", err)
+	}
+
+	token, err := zeidapi.ReadToken(*tokenFile)
+	if err != nil {
+		log.Fatalf("failed to read API token: %+v", err)
+	}
+
+	baseURL, httpClient, err := zeidapi.NewHTTPClient(*apiAddress, zeidapi.ClientOptions{
+		Token:    token,
+		CAFile:   *tlsCA,
+		CertFile: *tlsCert,
+		KeyFile:  *tlsKey,
+	})
+	if err != nil {
+		log.Fatalf("failed to create API client: %+v", err)
+	}
+
+	client := zeid.NewZeiProtobufClient(baseURL, httpClient)
 
 	log.Printf("start")
 	systray.Run(onReady(client), onExit)
@@ -30,7 +63,7 @@ func onReady(client zeid.Zei) func() {
 		log.Printf("ready")
 		systray.SetTitle("zei")
 		systray.SetIcon(Icon)
-		systray.SetTooltip("hello")
+		systray.SetTooltip("zei")
 
 		updateMenu(client)
 	}
@@ -39,23 +72,38 @@ func onReady(client zeid.Zei) func() {
 func updateMenu(client zeid.Zei) {
 	ctx := context.Background()
 
-	currentActivity, err := client.CurrentActivity(ctx, &zeid.CurrentActivityReq{})
-	if err != nil {
-		log.Printf("failed to get current activity: %+v", err)
-	}
+	systray.AddSeparator()
 
-	startTime, err := time.Parse(time.RFC3339, currentActivity.StartTime)
-	if err != nil {
-		log.Printf("failed to parse startTime: %+v", err)
-	}
+	currentActivityMenu := systray.AddMenuItem("zeid unavailable", "")
+
+	startDaemonMenu := systray.AddMenuItem("Start zeid", fmt.Sprintf("Start the %s systemd user unit", *zeidUnit))
+	retry := make(chan struct{}, 1)
+	go func() {
+		for range startDaemonMenu.ClickedCh {
+			startDaemon(startDaemonMenu)
+
+			select {
+			case retry <- struct{}{}:
+			default:
+			}
+		}
+	}()
 
 	systray.AddSeparator()
 
-	currentActivityMenu := systray.AddMenuItem("Not tracking", "")
-	if currentActivity.Activity != nil {
-		currentActivityMenu.SetTitle(formatCurrentActivity(currentActivity.Activity, startTime, currentActivity.IsIdle))
+	activities := newActivityMenu(client)
+	activities.setAvailable(ctx, false)
+	go activities.watch(ctx)
 
-	}
+	profiles := newProfileMenu(client, func() { activities.refresh(ctx) })
+	profiles.setAvailable(ctx, false)
+	go profiles.watch(ctx)
+
+	systray.AddSeparator()
+
+	history := newHistoryMenu(client)
+	history.setAvailable(ctx, false)
+	go history.watch(ctx)
 
 	systray.AddSeparator()
 
@@ -65,23 +113,123 @@ func updateMenu(client zeid.Zei) {
 		systray.Quit()
 	}()
 
-	for _ = range time.Tick(time.Second) {
-		currentActivity, err := client.CurrentActivity(ctx, &zeid.CurrentActivityReq{})
+	var (
+		available bool
+		delay     = time.Second
+	)
+
+	for {
+		currentActivity, err := client.CurrentActivity(ctx, &zeid.CurrentActivityReq{Device: *device})
 		if err != nil {
-			log.Printf("failed to get current activity: %+v", err)
+			if available {
+				log.Printf("failed to get current activity: %+v", err)
+			}
+
+			available = false
+			delay = reconnectDelay(delay)
+
+			currentActivityMenu.SetTitle("zeid unavailable")
+			currentActivityMenu.SetTooltip(err.Error())
+			startDaemonMenu.Show()
+			activities.setAvailable(ctx, false)
+			profiles.setAvailable(ctx, false)
+			history.setAvailable(ctx, false)
+			updateStatus(nil, err)
 		} else {
-			startTime, err := time.Parse(time.RFC3339, currentActivity.StartTime)
-			if err != nil {
-				log.Printf("failed to parse startTime: %+v", err)
+			if !available {
+				log.Printf("connected to zeid")
 			}
 
-			currentActivityMenu.SetTitle(formatCurrentActivity(
-				currentActivity.Activity,
-				startTime,
-				currentActivity.IsIdle,
-			))
+			available = true
+			delay = time.Second
+
+			startDaemonMenu.Hide()
+			activities.setAvailable(ctx, true)
+			profiles.setAvailable(ctx, true)
+			history.setAvailable(ctx, true)
+			updateStatus(currentActivity, nil)
+			updateCurrentActivity(currentActivityMenu, currentActivity)
+			activities.setCurrent(currentActivity.Activity.GetId())
 		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-retry:
+			timer.Stop()
+			delay = time.Second
+		}
+	}
+}
+
+func updateCurrentActivity(item *systray.MenuItem, currentActivity *zeid.CurrentActivityResp) {
+	if currentActivity.IsIdle {
+		item.SetTitle(formatCurrentActivity(currentActivity.Activity, time.Time{}, true))
+		item.SetTooltip("")
+		return
+	}
+
+	startTime, err := ptypes.Timestamp(currentActivity.StartTime)
+	if err != nil {
+		log.Printf("failed to parse startTime: %+v", err)
+	}
+
+	title := formatCurrentActivity(currentActivity.Activity, startTime, false)
+	if currentActivity.Focus != nil {
+		title = fmt.Sprintf("%s (%s)", title, formatFocus(currentActivity.Focus))
+	}
+
+	item.SetTitle(title)
+	item.SetTooltip(fmt.Sprintf("Started at %s", startTime.In(location).Format("15:04 MST")))
+}
+
+// updateStatus updates the tray icon and tooltip with the current activity,
+// or the error which occurred while requesting it.
+func updateStatus(currentActivity *zeid.CurrentActivityResp, err error) {
+	if err != nil {
+		setIcon(iconState{kind: errorIcon})
+		systray.SetTooltip(fmt.Sprintf("zeid: %s", err))
+		return
 	}
+
+	device := "Device connected"
+	if !currentActivity.DeviceConnected {
+		device = "Device disconnected"
+	}
+
+	switch {
+	case !currentActivity.DeviceConnected:
+		setIcon(iconState{kind: disconnectedIcon})
+	case currentActivity.IsIdle:
+		setIcon(iconState{kind: idleIcon})
+	default:
+		setIcon(iconState{kind: trackingIcon, color: currentActivity.Activity.GetColor()})
+	}
+
+	startTime, err := ptypes.Timestamp(currentActivity.StartTime)
+	if err != nil && !currentActivity.IsIdle {
+		log.Printf("failed to parse startTime: %+v", err)
+	}
+
+	systray.SetTooltip(fmt.Sprintf(
+		"%s\n%s",
+		formatCurrentActivity(currentActivity.Activity, startTime, currentActivity.IsIdle),
+		device,
+	))
+}
+
+func formatFocus(focus *zeid.FocusSession) string {
+	remaining, err := ptypes.Duration(focus.Remaining)
+	if err != nil {
+		log.Printf("failed to parse focus remaining time: %+v", err)
+	}
+
+	phase := "focus"
+	if focus.Phase == zeid.FocusPhase_FOCUS_BREAK {
+		phase = "break"
+	}
+
+	return fmt.Sprintf("%s, %s left", phase, remaining.Truncate(time.Second).String())
 }
 
 func formatCurrentActivity(a *zeid.Activity, startTime time.Time, idle bool) string {
@@ -89,7 +237,7 @@ func formatCurrentActivity(a *zeid.Activity, startTime time.Time, idle bool) str
 		return "Not tracking"
 	}
 
-	return fmt.Sprintf("%s - %s", a.Name, time.Since(startTime).Truncate(time.Second).String())
+	return fmt.Sprintf("%s - %s", a.Name, clk.Since(startTime).Truncate(time.Second).String())
 }
 
 func onExit() {
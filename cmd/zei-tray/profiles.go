@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/getlantern/systray"
+)
+
+const (
+	maxProfileItems = 8
+
+	profilesRefreshInterval = time.Minute
+)
+
+// profileMenu lists the profiles of the device and switches between them,
+// it is hidden unless zeid has several profiles.
+type profileMenu struct {
+	client   zeid.Zei
+	onSwitch func()
+
+	mu        sync.Mutex
+	available bool
+	profiles  *zeid.ListProfilesResp
+	open      bool
+
+	profileItem  *systray.MenuItem
+	profileItems []*systray.MenuItem
+}
+
+func newProfileMenu(client zeid.Zei, onSwitch func()) *profileMenu {
+	m := &profileMenu{client: client, onSwitch: onSwitch}
+
+	m.profileItem = systray.AddMenuItem("Profile ▸", "Switch to another profile of the device")
+	m.profileItem.Hide()
+	go func() {
+		for range m.profileItem.ClickedCh {
+			m.toggle()
+		}
+	}()
+
+	for i := 0; i < maxProfileItems; i++ {
+		item := systray.AddMenuItem("", "Switch to this profile")
+		item.Hide()
+
+		m.profileItems = append(m.profileItems, item)
+		go func(i int) {
+			for range item.ClickedCh {
+				m.switchProfile(i)
+			}
+		}(i)
+	}
+
+	return m
+}
+
+// watch refreshes the profiles periodically, they may be switched from
+// other clients.
+func (m *profileMenu) watch(ctx context.Context) {
+	for range time.Tick(profilesRefreshInterval) {
+		m.mu.Lock()
+		available := m.available
+		m.mu.Unlock()
+
+		if available {
+			m.refresh(ctx)
+		}
+	}
+}
+
+// setAvailable shows the profiles while zeid is available, and refreshes
+// them once it becomes available again.
+func (m *profileMenu) setAvailable(ctx context.Context, available bool) {
+	m.mu.Lock()
+	changed := available != m.available
+	m.available = available
+
+	if !available {
+		m.profileItem.Hide()
+		for _, item := range m.profileItems {
+			item.Hide()
+		}
+	}
+	m.mu.Unlock()
+
+	if available && changed {
+		m.refresh(ctx)
+	}
+}
+
+func (m *profileMenu) refresh(ctx context.Context) {
+	res, err := m.client.ListProfiles(ctx, &zeid.ListProfilesReq{Device: *device})
+	if err != nil {
+		log.Printf("failed to list profiles: %+v", err)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.profiles = res
+	m.update()
+}
+
+// update shows the profiles, unless zeid is unavailable or has a single
+// profile.
+func (m *profileMenu) update() {
+	if !m.available || m.profiles == nil {
+		return
+	}
+
+	if len(m.profiles.Profiles) < 2 {
+		m.profileItem.Hide()
+	} else {
+		arrow := "▸"
+		if m.open {
+			arrow = "▾"
+		}
+
+		m.profileItem.SetTitle(fmt.Sprintf("Profile: %s %s", m.profiles.Active, arrow))
+		m.profileItem.Show()
+	}
+
+	for i, item := range m.profileItems {
+		if i >= len(m.profiles.Profiles) || len(m.profiles.Profiles) < 2 || !m.open {
+			item.Hide()
+			continue
+		}
+
+		name := m.profiles.Profiles[i].Name
+		if name == m.profiles.Active {
+			item.SetTitle(fmt.Sprintf("    ✓ %s", name))
+		} else {
+			item.SetTitle(fmt.Sprintf("       %s", name))
+		}
+		item.Show()
+	}
+}
+
+func (m *profileMenu) toggle() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.open = !m.open
+	m.update()
+}
+
+func (m *profileMenu) switchProfile(i int) {
+	m.mu.Lock()
+	var name string
+	if m.profiles != nil && i < len(m.profiles.Profiles) && m.profiles.Profiles[i].Name != m.profiles.Active {
+		name = m.profiles.Profiles[i].Name
+	}
+	m.mu.Unlock()
+
+	if name == "" {
+		return
+	}
+
+	ctx := context.Background()
+
+	_, err := m.client.SwitchProfile(ctx, &zeid.SwitchProfileReq{Device: *device, Name: name})
+	if err != nil {
+		log.Printf("failed to switch profile: %+v", err)
+		return
+	}
+
+	m.refresh(ctx)
+	m.onSwitch()
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getlantern/systray"
+)
+
+const iconSize = 64
+
+var (
+	defaultActivityColor = color.RGBA{0x4a, 0x90, 0xe2, 0xff}
+	idleColor            = color.RGBA{0x9b, 0x9b, 0x9b, 0xff}
+	errorColor           = color.RGBA{0xd0, 0x02, 0x1b, 0xff}
+	markColor            = color.RGBA{0xff, 0xff, 0xff, 0xff}
+)
+
+// iconState is what the tray icon shows.
+type iconState struct {
+	kind  iconKind
+	color string
+}
+
+type iconKind int
+
+const (
+	trackingIcon iconKind = iota
+	idleIcon
+	disconnectedIcon
+	errorIcon
+)
+
+var (
+	iconMu      sync.Mutex
+	iconCurrent *iconState
+	iconCache   = map[iconState][]byte{}
+)
+
+// setIcon changes the tray icon, unless it already shows the state.
+func setIcon(state iconState) {
+	iconMu.Lock()
+	defer iconMu.Unlock()
+
+	if iconCurrent != nil && *iconCurrent == state {
+		return
+	}
+
+	icon, ok := iconCache[state]
+	if !ok {
+		var err error
+		icon, err = renderIcon(state)
+		if err != nil {
+			log.Printf("failed to render icon: %+v", err)
+			icon = Icon
+		}
+		iconCache[state] = icon
+	}
+
+	systray.SetIcon(icon)
+	iconCurrent = &state
+}
+
+// renderIcon draws a disc of the activity color when tracking, a ring when
+// idle, a crossed ring when the device is disconnected and a barred red disc
+// on errors.
+func renderIcon(state iconState) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, iconSize, iconSize))
+
+	var (
+		center = float64(iconSize) / 2
+		outer  = center - 4
+		inner  = outer - 8
+		fill   = parseColor(state.color)
+	)
+
+	for y := 0; y < iconSize; y++ {
+		for x := 0; x < iconSize; x++ {
+			dx, dy := float64(x)+0.5-center, float64(y)+0.5-center
+			dist := math.Sqrt(dx*dx + dy*dy)
+			if dist > outer {
+				continue
+			}
+
+			switch state.kind {
+			case trackingIcon:
+				img.Set(x, y, fill)
+			case idleIcon:
+				if dist >= inner {
+					img.Set(x, y, idleColor)
+				}
+			case disconnectedIcon:
+				if dist >= inner || math.Abs(dx+dy) < 5 {
+					img.Set(x, y, idleColor)
+				}
+			case errorIcon:
+				if math.Abs(dy) < 5 && math.Abs(dx) < inner-4 {
+					img.Set(x, y, markColor)
+				} else {
+					img.Set(x, y, errorColor)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// parseColor parses colors of activities, formatted as #rrggbb.
+func parseColor(s string) color.RGBA {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return defaultActivityColor
+	}
+
+	rgb, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return defaultActivityColor
+	}
+
+	return color.RGBA{uint8(rgb >> 16), uint8(rgb >> 8), uint8(rgb), 0xff}
+}
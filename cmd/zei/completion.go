@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/pauldub/zei/pkg/zeidapi"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/posener/complete"
+)
+
+// completionTimeout bounds the request of activities while completing, so
+// the shell doesn't hang when zeid isn't running.
+const completionTimeout = 2 * time.Second
+
+// completionRawEnv is set by the fish completion, fish escapes the candidates
+// itself.
+const completionRawEnv = "ZEI_COMPLETE_RAW"
+
+// completionSpecialChars are escaped in the candidates, bash and zsh insert
+// them as they are printed.
+const completionSpecialChars = " \t\n\\'\"`$&|;<>()[]{}*?!#~"
+
+// newCompletion returns the completion of the zei commands, it is built from
+// the kingpin model so it stays in sync with the commands.
+func newCompletion() *complete.Complete {
+	model := app.Model()
+
+	cmd := complete.Command{
+		Sub: complete.Commands{},
+		GlobalFlags: completionFlags(model.FlagGroupModel, map[string]complete.Predictor{
+			"device": predictDevices(),
+		}),
+	}
+
+	for _, c := range model.Commands {
+		addCompletionCommand(cmd.Sub, c)
+	}
+
+	return complete.New(filepath.Base(os.Args[0]), cmd)
+}
+
+func addCompletionCommand(commands complete.Commands, model *kingpin.CmdModel) {
+	if model.Hidden {
+		return
+	}
+
+	predictors := map[string]complete.Predictor{}
+	if model.FullCommand == assignActivity.FullCommand() {
+		predictors["id"] = predictActivities(false)
+		predictors["name"] = predictActivities(true)
+		predictors["side"] = complete.PredictSet("1", "2", "3", "4", "5", "6", "7", "8")
+	}
+
+	cmd := complete.Command{
+		Sub:   complete.Commands{},
+		Flags: completionFlags(model.FlagGroupModel, predictors),
+	}
+
+	switch model.FullCommand {
+	case startActivity.FullCommand():
+		cmd.Args = predictActivities(true)
+	case switchProfile.FullCommand():
+		cmd.Args = predictProfiles()
+	case applyLayout.FullCommand():
+		cmd.Args = complete.PredictOr(complete.PredictFiles("*.yaml"), complete.PredictFiles("*.yml"))
+	case completion.FullCommand():
+		cmd.Args = complete.PredictSet("bash", "zsh", "fish")
+	}
+
+	for _, c := range model.Commands {
+		addCompletionCommand(cmd.Sub, c)
+	}
+
+	commands[model.Name] = cmd
+	for _, alias := range model.Aliases {
+		commands[alias] = cmd
+	}
+}
+
+func completionFlags(model *kingpin.FlagGroupModel, predictors map[string]complete.Predictor) complete.Flags {
+	flags := complete.Flags{}
+
+	for _, f := range model.Flags {
+		if f.Hidden {
+			continue
+		}
+
+		predictor, ok := predictors[f.Name]
+		switch {
+		case ok:
+		case f.IsBoolFlag():
+			predictor = complete.PredictNothing
+		default:
+			predictor = complete.PredictAnything
+		}
+
+		flags["--"+f.Name] = predictor
+		if f.Short != 0 {
+			flags["-"+string(f.Short)] = predictor
+		}
+	}
+
+	return flags
+}
+
+// predictActivities completes the IDs of activities, along with their names
+// when names is true, by requesting them from zeid.
+func predictActivities(names bool) complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+		defer cancel()
+
+		client, err := completionClient()
+		if err != nil {
+			return nil
+		}
+
+		res, err := client.ListActivities(ctx, &zeid.ListActivitiesReq{Device: completionFlag("device", "ZEI_DEVICE", "")})
+		if err != nil {
+			return nil
+		}
+
+		var predictions []string
+		for _, activity := range res.Activities {
+			if names {
+				predictions = append(predictions, escapeCandidate(activity.Name))
+			}
+			predictions = append(predictions, escapeCandidate(activity.Id))
+		}
+
+		return predictions
+	})
+}
+
+// predictProfiles completes the names of profiles by requesting them from
+// zeid.
+func predictProfiles() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+		defer cancel()
+
+		client, err := completionClient()
+		if err != nil {
+			return nil
+		}
+
+		res, err := client.ListProfiles(ctx, &zeid.ListProfilesReq{Device: completionFlag("device", "ZEI_DEVICE", "")})
+		if err != nil {
+			return nil
+		}
+
+		var predictions []string
+		for _, profile := range res.Profiles {
+			predictions = append(predictions, escapeCandidate(profile.Name))
+		}
+
+		return predictions
+	})
+}
+
+// predictDevices completes the serial numbers of the devices by requesting
+// them from zeid.
+func predictDevices() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+		defer cancel()
+
+		client, err := completionClient()
+		if err != nil {
+			return nil
+		}
+
+		res, err := client.ListDevices(ctx, &zeid.ListDevicesReq{})
+		if err != nil {
+			return nil
+		}
+
+		var predictions []string
+		for _, device := range res.Devices {
+			predictions = append(predictions, escapeCandidate(device.Serial))
+		}
+
+		return predictions
+	})
+}
+
+// escapeCandidate escapes the characters of a candidate which the shell
+// would split or expand, so names with spaces are completed as one word.
+func escapeCandidate(candidate string) string {
+	if os.Getenv(completionRawEnv) != "" {
+		return candidate
+	}
+
+	var escaped strings.Builder
+	for _, r := range candidate {
+		if strings.ContainsRune(completionSpecialChars, r) {
+			escaped.WriteByte('\\')
+		}
+		escaped.WriteRune(r)
+	}
+
+	return escaped.String()
+}
+
+// completionClient returns a client of the API server given on the command
+// line being completed.
+func completionClient() (zeid.Zei, error) {
+	return newClient(
+		completionFlag("api", "ZEI_API", zeidapi.DefaultAddress()),
+		completionFlag("token-file", "ZEI_TOKEN_FILE", zeidapi.DefaultTokenPath()),
+		zeidapi.ClientOptions{
+			CAFile:   completionFlag("tls-ca", "ZEI_TLS_CA", ""),
+			CertFile: completionFlag("tls-cert", "ZEI_TLS_CERT", ""),
+			KeyFile:  completionFlag("tls-key", "ZEI_TLS_KEY", ""),
+		},
+	)
+}
+
+// completionFlag returns the value of a global flag given on the command
+// line being completed, arguments are not parsed yet. It falls back to the
+// environment variable of the flag, then to its default value.
+func completionFlag(name, envar, value string) string {
+	args := strings.Fields(os.Getenv("COMP_LINE"))
+
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "--"+name+"=") {
+			return strings.TrimPrefix(arg, "--"+name+"=")
+		}
+		if arg == "--"+name && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+
+	if v := os.Getenv(envar); v != "" {
+		return v
+	}
+
+	return value
+}
+
+// completionScript returns the script registering the completion of zei in
+// the given shell, completions are computed by zei itself.
+func completionScript(shell string) (string, error) {
+	bin, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	name := filepath.Base(os.Args[0])
+
+	switch shell {
+	case "bash":
+		return fmt.Sprintf("complete -C %q %s\n", bin, name), nil
+	case "zsh":
+		return fmt.Sprintf("autoload -U +X bashcompinit && bashcompinit\ncomplete -o nospace -C %q %s\n", bin, name), nil
+	case "fish":
+		return fmt.Sprintf(`function __complete_%[2]s
+    set -lx COMP_LINE (commandline -cp)
+    set -lx %[3]s 1
+    test -z (commandline -ct)
+    and set COMP_LINE "$COMP_LINE "
+    %[1]q
+end
+complete -f -c %[2]s -a "(__complete_%[2]s)"
+`, bin, name, completionRawEnv), nil
+	}
+
+	return "", fmt.Errorf("unsupported shell %q", shell)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestEscapeCandidate(t *testing.T) {
+	cases := []struct {
+		candidate string
+		want      string
+	}{
+		{candidate: "Coding", want: "Coding"},
+		{candidate: "Deep work", want: `Deep\ work`},
+		{candidate: "R&D (2019)", want: `R\&D\ \(2019\)`},
+		{candidate: `"Ops" $team`, want: `\"Ops\"\ \$team`},
+		{candidate: `back\slash`, want: `back\\slash`},
+		{candidate: "Café", want: "Café"},
+	}
+
+	for _, c := range cases {
+		if got := escapeCandidate(c.candidate); got != c.want {
+			t.Errorf("escapeCandidate(%q) = %q, want %q", c.candidate, got, c.want)
+		}
+	}
+
+	os.Setenv(completionRawEnv, "1")
+	defer os.Unsetenv(completionRawEnv)
+
+	for _, c := range cases {
+		if got := escapeCandidate(c.candidate); got != c.candidate {
+			t.Errorf("escapeCandidate(%q) = %q with %s set, want it unescaped", c.candidate, got, completionRawEnv)
+		}
+	}
+}
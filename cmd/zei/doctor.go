@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pauldub/zei/pkg/version"
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pauldub/zei/pkg/zeidapi"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/twitchtv/twirp"
+)
+
+// Statuses of the checks of the doctor command.
+const (
+	checkOK      = "ok"
+	checkWarning = "warning"
+	checkError   = "error"
+)
+
+const (
+	// weakRSSI is the signal strength, in dBm, under which the connection
+	// to a device is unreliable.
+	weakRSSI = -85
+
+	// slowAPI is the latency of the API above which it is reported.
+	slowAPI = 2 * time.Second
+)
+
+type (
+	doctorCheck struct {
+		Name    string `json:"name" yaml:"name"`
+		Status  string `json:"status" yaml:"status"`
+		Message string `json:"message" yaml:"message"`
+		Hint    string `json:"hint,omitempty" yaml:"hint,omitempty"`
+	}
+
+	doctorOutput struct {
+		Checks  []doctorCheck `json:"checks" yaml:"checks"`
+		Healthy bool          `json:"healthy" yaml:"healthy"`
+	}
+)
+
+// runDoctor diagnoses the common problems preventing tracking, from the
+// host up to the API of each device.
+func runDoctor(ctx context.Context, client zeid.Zei, location *time.Location) doctorOutput {
+	var checks []doctorCheck
+
+	if check := adapterCheck(); check != nil {
+		checks = append(checks, *check)
+	}
+	if check := capabilitiesCheck(); check != nil {
+		checks = append(checks, *check)
+	}
+
+	checks = append(checks, healthChecks(ctx, client, location)...)
+
+	if check := credentialsCheck(ctx); check != nil {
+		checks = append(checks, *check)
+	}
+
+	out := doctorOutput{Checks: checks, Healthy: true}
+	for _, check := range checks {
+		if check.Status == checkError {
+			out.Healthy = false
+		}
+	}
+
+	return out
+}
+
+func healthChecks(ctx context.Context, client zeid.Zei, location *time.Location) []doctorCheck {
+	health, err := client.Health(ctx, &zeid.HealthReq{})
+	if twerr, ok := err.(twirp.Error); ok && twerr.Code() == twirp.Unauthenticated {
+		return []doctorCheck{{
+			Name:    "zeid",
+			Status:  checkError,
+			Message: fmt.Sprintf("zeid rejected the API token: %s", twerr.Msg()),
+			Hint:    fmt.Sprintf("point --token-file to the -api-token-file of zeid, %s by default", zeidapi.DefaultTokenPath()),
+		}}
+	}
+	if err != nil {
+		return []doctorCheck{{
+			Name:    "zeid",
+			Status:  checkError,
+			Message: fmt.Sprintf("zeid is not reachable at %s: %s", *apiAddress, err),
+			Hint:    "start zeid, e.g. with 'systemctl --user start zeid.service', or point --api to it",
+		}}
+	}
+
+	check := doctorCheck{
+		Name:    "zeid",
+		Status:  checkOK,
+		Message: fmt.Sprintf("zeid %s is running", health.Version),
+	}
+	if startedAt, err := ptypes.Timestamp(health.StartedAt); err == nil {
+		check.Message = fmt.Sprintf("%s since %s", check.Message, formatTime(location, startedAt))
+	}
+	if health.Version != version.VERSION {
+		check.Status = checkWarning
+		check.Hint = fmt.Sprintf("zei is version %s, upgrade both to the same version", version.VERSION)
+	}
+
+	checks := []doctorCheck{check}
+
+	if len(health.Devices) == 0 {
+		return append(checks, doctorCheck{
+			Name:    "device",
+			Status:  checkError,
+			Message: "no device is connected to zeid",
+			Hint:    "flip the device to wake it up and keep it close to the Bluetooth adapter",
+		})
+	}
+
+	for _, d := range health.Devices {
+		checks = append(checks, deviceCheck(d, location), apiCheck(d))
+	}
+
+	return checks
+}
+
+func deviceCheck(d *zeid.DeviceHealth, location *time.Location) doctorCheck {
+	check := doctorCheck{Name: fmt.Sprintf("device %s", d.Serial)}
+
+	switch {
+	case !d.Connected:
+		check.Status = checkError
+		check.Message = "the device is disconnected"
+		check.Hint = "flip the device to wake it up, zeid reconnects to it automatically"
+	case d.Rssi < weakRSSI:
+		check.Status = checkWarning
+		check.Message = fmt.Sprintf("the signal is weak (%d dBm)", d.Rssi)
+		check.Hint = "move the device closer to the Bluetooth adapter"
+	default:
+		check.Status = checkOK
+		check.Message = fmt.Sprintf("connected (%d dBm)", d.Rssi)
+	}
+
+	if at, err := ptypes.Timestamp(d.LastOrientation); err == nil && d.LastOrientation != nil {
+		check.Message = fmt.Sprintf("%s, last flipped %s", check.Message, formatTime(location, at))
+	}
+
+	return check
+}
+
+func apiCheck(d *zeid.DeviceHealth) doctorCheck {
+	check := doctorCheck{Name: fmt.Sprintf("api %s", d.Serial)}
+
+	latency, _ := ptypes.Duration(d.ApiLatency)
+	latency = latency.Truncate(time.Millisecond)
+
+	switch {
+	case !d.ApiReachable:
+		check.Status = checkError
+		check.Message = fmt.Sprintf("the API is unreachable: %s", d.ApiError)
+		check.Hint = "check the network connection of the host running zeid"
+	case d.TokenChecked && !d.TokenValid:
+		check.Status = checkError
+		check.Message = fmt.Sprintf("the API rejected the access token of profile %s: %s", d.Profile, d.ApiError)
+		check.Hint = "check the API key and secret of the account, then restart zeid"
+	case d.ApiError != "":
+		check.Status = checkWarning
+		check.Message = fmt.Sprintf("the API answered with an error: %s", d.ApiError)
+	case latency > slowAPI:
+		check.Status = checkWarning
+		check.Message = fmt.Sprintf("the API is slow to answer (%s)", latency)
+	default:
+		check.Status = checkOK
+		check.Message = fmt.Sprintf("signed in with profile %s (%s)", d.Profile, latency)
+	}
+
+	if d.QueueDepth > 0 {
+		check.Message = fmt.Sprintf("%s, %d activity changes waiting to be tracked", check.Message, d.QueueDepth)
+	}
+
+	return check
+}
+
+// credentialsCheck signs in with the credentials of the environment of the
+// zeid unit, when they are set.
+func credentialsCheck(ctx context.Context) *doctorCheck {
+	apiKey, apiSecret := os.Getenv("ZEI_API_KEY"), os.Getenv("ZEI_API_SECRET")
+	if apiKey == "" && apiSecret == "" {
+		return nil
+	}
+
+	check := &doctorCheck{Name: "credentials"}
+
+	_, err := zei.NewClient().DeveloperSignIn(ctx, apiKey, apiSecret)
+	switch {
+	case err == nil:
+		check.Status = checkOK
+		check.Message = "ZEI_API_KEY and ZEI_API_SECRET are valid"
+	case zei.IsUnauthorized(err):
+		check.Status = checkError
+		check.Message = "ZEI_API_KEY and ZEI_API_SECRET were rejected by the API"
+		check.Hint = "generate a new API key and secret in the Timeular settings"
+	default:
+		check.Status = checkWarning
+		check.Message = fmt.Sprintf("failed to check ZEI_API_KEY and ZEI_API_SECRET: %s", err)
+	}
+
+	return check
+}
+
+func (out doctorOutput) String() string {
+	var lines []string
+
+	for _, check := range out.Checks {
+		mark := "✓"
+		switch check.Status {
+		case checkWarning:
+			mark = highlight("!")
+		case checkError:
+			mark = highlight("✗")
+		}
+
+		lines = append(lines, fmt.Sprintf("%s %s: %s", mark, check.Name, check.Message))
+		if check.Hint != "" {
+			lines = append(lines, fmt.Sprintf("    %s", check.Hint))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
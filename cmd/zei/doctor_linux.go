@@ -0,0 +1,124 @@
+// +build linux
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+const (
+	sysBluetooth = "/sys/class/bluetooth"
+
+	// capabilities required by zeid to open raw HCI sockets.
+	capNetAdmin = 12
+	capNetRaw   = 13
+)
+
+// adapterCheck checks that a Bluetooth adapter is present and not blocked.
+func adapterCheck() *doctorCheck {
+	check := &doctorCheck{Name: "bluetooth adapter"}
+
+	adapters, _ := filepath.Glob(filepath.Join(sysBluetooth, "hci*"))
+	if len(adapters) == 0 {
+		check.Status = checkError
+		check.Message = "no Bluetooth adapter was found"
+		check.Hint = "plug a Bluetooth 4.0 adapter in, or load the btusb kernel module"
+		return check
+	}
+
+	var available []string
+	for _, adapter := range adapters {
+		if !rfkillBlocked(adapter) {
+			available = append(available, filepath.Base(adapter))
+		}
+	}
+
+	if len(available) == 0 {
+		check.Status = checkError
+		check.Message = "the Bluetooth adapters are blocked"
+		check.Hint = "unblock them with 'rfkill unblock bluetooth'"
+		return check
+	}
+
+	check.Status = checkOK
+	check.Message = strings.Join(available, ", ")
+
+	return check
+}
+
+func rfkillBlocked(adapter string) bool {
+	switches, _ := filepath.Glob(filepath.Join(adapter, "rfkill*"))
+
+	for _, s := range switches {
+		for _, state := range []string{"soft", "hard"} {
+			b, err := ioutil.ReadFile(filepath.Join(s, state))
+			if err == nil && strings.TrimSpace(string(b)) == "1" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// capabilitiesCheck checks that the zeid binary is allowed to open raw HCI
+// sockets, unless it is run as root.
+func capabilitiesCheck() *doctorCheck {
+	check := &doctorCheck{Name: "capabilities"}
+
+	path, err := exec.LookPath("zeid")
+	if err != nil {
+		check.Status = checkWarning
+		check.Message = "zeid was not found in PATH"
+		return check
+	}
+
+	hint := fmt.Sprintf("allow it with 'sudo setcap cap_net_raw,cap_net_admin+eip %s', unless zeid runs as root", path)
+
+	permitted, err := permittedCapabilities(path)
+	if err != nil {
+		check.Status = checkWarning
+		check.Message = fmt.Sprintf("%s has no capabilities", path)
+		check.Hint = hint
+		return check
+	}
+
+	for _, c := range []struct {
+		bit  uint
+		name string
+	}{{capNetRaw, "cap_net_raw"}, {capNetAdmin, "cap_net_admin"}} {
+		if permitted&(1<<c.bit) == 0 {
+			check.Status = checkWarning
+			check.Message = fmt.Sprintf("%s lacks %s", path, c.name)
+			check.Hint = hint
+			return check
+		}
+	}
+
+	check.Status = checkOK
+	check.Message = fmt.Sprintf("%s has cap_net_raw and cap_net_admin", path)
+
+	return check
+}
+
+// permittedCapabilities returns the low bits of the permitted capabilities
+// of the file at path, from its security.capability attribute.
+func permittedCapabilities(path string) (uint32, error) {
+	b := make([]byte, 24)
+
+	n, err := syscall.Getxattr(path, "security.capability", b)
+	if err != nil {
+		return 0, err
+	}
+	if n < 8 {
+		return 0, fmt.Errorf("invalid capabilities of %s", path)
+	}
+
+	return binary.LittleEndian.Uint32(b[4:8]), nil
+}
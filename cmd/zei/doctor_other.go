@@ -0,0 +1,14 @@
+// +build !linux
+
+package main
+
+// adapterCheck is only supported on Linux.
+func adapterCheck() *doctorCheck {
+	return nil
+}
+
+// capabilitiesCheck is only supported on Linux, where raw HCI sockets
+// require capabilities.
+func capabilitiesCheck() *doctorCheck {
+	return nil
+}
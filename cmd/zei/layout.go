@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// layoutFile is the declaration of the activities of the sides of a device.
+type layoutFile struct {
+	Sides []layoutFileSide `json:"sides" yaml:"sides"`
+}
+
+type layoutFileSide struct {
+	Side     int64  `json:"side" yaml:"side"`
+	Activity string `json:"activity" yaml:"activity"`
+	Color    string `json:"color,omitempty" yaml:"color,omitempty"`
+}
+
+type (
+	layoutChangeOutput struct {
+		Change   string          `json:"change" yaml:"change"`
+		Side     int64           `json:"side" yaml:"side"`
+		Activity *activityOutput `json:"activity" yaml:"activity"`
+		Previous *activityOutput `json:"previous,omitempty" yaml:"previous,omitempty"`
+	}
+
+	applyLayoutOutput struct {
+		Changes []layoutChangeOutput `json:"changes" yaml:"changes"`
+		Applied bool                 `json:"applied" yaml:"applied"`
+	}
+
+	profileOutput struct {
+		Name       string           `json:"name" yaml:"name"`
+		Active     bool             `json:"active" yaml:"active"`
+		OwnAccount bool             `json:"own_account" yaml:"own_account"`
+		Sides      []layoutFileSide `json:"sides" yaml:"sides"`
+	}
+
+	profilesOutput struct {
+		Profiles []profileOutput `json:"profiles" yaml:"profiles"`
+		Active   string          `json:"active" yaml:"active"`
+	}
+
+	switchProfileOutput struct {
+		Profile string               `json:"profile" yaml:"profile"`
+		Changes []layoutChangeOutput `json:"changes" yaml:"changes"`
+	}
+)
+
+func readLayout(path string) ([]*zeid.LayoutSide, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var layout layoutFile
+
+	err = yaml.UnmarshalStrict(b, &layout)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse layout")
+	}
+
+	sides := make([]*zeid.LayoutSide, 0, len(layout.Sides))
+	for _, s := range layout.Sides {
+		sides = append(sides, &zeid.LayoutSide{
+			Number:       s.Side,
+			ActivityName: s.Activity,
+			Color:        s.Color,
+		})
+	}
+
+	return sides, nil
+}
+
+// newLayoutFileOutput returns the layout of the assigned sides, and the
+// layout file declaring it as text.
+func newLayoutFileOutput(res *zeid.ListSidesResp) (layoutFile, string, error) {
+	layout := layoutFile{Sides: []layoutFileSide{}}
+
+	for _, s := range res.Sides {
+		if s.Activity == nil {
+			continue
+		}
+
+		layout.Sides = append(layout.Sides, layoutFileSide{
+			Side:     s.Number,
+			Activity: s.Activity.Name,
+			Color:    s.Activity.Color,
+		})
+	}
+
+	b, err := yaml.Marshal(layout)
+	if err != nil {
+		return layout, "", err
+	}
+
+	return layout, strings.TrimSuffix(string(b), "\n"), nil
+}
+
+func newApplyLayoutOutput(res *zeid.ApplyLayoutResp) (applyLayoutOutput, string) {
+	changes, text := newLayoutChangesOutput(res.Changes)
+
+	switch {
+	case len(res.Changes) == 0:
+		text = append(text, "The device already matches the layout.")
+	case !res.Applied:
+		text = append(text, "Dry run, no change was applied.")
+	default:
+		text = append(text, "The layout was applied.")
+	}
+
+	return applyLayoutOutput{Changes: changes, Applied: res.Applied}, strings.Join(text, "\n")
+}
+
+func newSwitchProfileOutput(res *zeid.SwitchProfileResp) (switchProfileOutput, string) {
+	changes, text := newLayoutChangesOutput(res.Changes)
+	text = append(text, fmt.Sprintf("Switched to profile %s.", res.Profile.GetName()))
+
+	return switchProfileOutput{Profile: res.Profile.GetName(), Changes: changes}, strings.Join(text, "\n")
+}
+
+// newLayoutChangesOutput returns the outputs of changes, along with a line
+// of text per change.
+func newLayoutChangesOutput(changes []*zeid.LayoutChange) ([]layoutChangeOutput, []string) {
+	var (
+		out  = make([]layoutChangeOutput, 0, len(changes))
+		text []string
+	)
+
+	for _, change := range changes {
+		var (
+			kind string
+			line string
+		)
+
+		switch change.Kind {
+		case zeid.LayoutChangeKind_LAYOUT_CREATE:
+			kind = "create"
+			line = fmt.Sprintf("+ side %d: %s (new activity)", change.Side, change.Activity.GetName())
+		case zeid.LayoutChangeKind_LAYOUT_ASSIGN:
+			kind = "assign"
+			line = fmt.Sprintf("+ side %d: %s", change.Side, change.Activity.GetName())
+		case zeid.LayoutChangeKind_LAYOUT_UNASSIGN:
+			kind = "unassign"
+			line = fmt.Sprintf("- side %d: %s", change.Side, change.Activity.GetName())
+		}
+
+		if change.Previous != nil {
+			line = fmt.Sprintf("~ side %d: %s -> %s", change.Side, change.Previous.Name, strings.TrimPrefix(line, fmt.Sprintf("+ side %d: ", change.Side)))
+		}
+
+		out = append(out, layoutChangeOutput{
+			Change:   kind,
+			Side:     change.Side,
+			Activity: newActivityOutput(change.Activity),
+			Previous: newActivityOutput(change.Previous),
+		})
+		text = append(text, line)
+	}
+
+	return out, text
+}
+
+func newProfilesOutput(res *zeid.ListProfilesResp) (profilesOutput, string) {
+	out := profilesOutput{
+		Profiles: make([]profileOutput, 0, len(res.Profiles)),
+		Active:   res.Active,
+	}
+	text := []string{"Profiles:"}
+
+	for _, p := range res.Profiles {
+		profile := profileOutput{
+			Name:       p.Name,
+			Active:     p.Name == res.Active,
+			OwnAccount: p.OwnAccount,
+			Sides:      make([]layoutFileSide, 0, len(p.Layout)),
+		}
+
+		for _, s := range p.Layout {
+			profile.Sides = append(profile.Sides, layoutFileSide{
+				Side:     s.Number,
+				Activity: s.ActivityName,
+				Color:    s.Color,
+			})
+		}
+
+		out.Profiles = append(out.Profiles, profile)
+
+		line := fmt.Sprintf("%s - %d sides", p.Name, len(p.Layout))
+		if len(p.Layout) == 0 {
+			line = fmt.Sprintf("%s - sides of the account", p.Name)
+		}
+		if p.OwnAccount {
+			line += ", own account"
+		}
+		if profile.Active {
+			line = highlight(line + " (active)")
+		}
+
+		text = append(text, line)
+	}
+
+	return out, strings.Join(text, "\n")
+}
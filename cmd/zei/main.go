@@ -4,84 +4,616 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"os"
+	"strings"
 	"time"
 
-	"github.com/pauldub/zei/rpc/zeid"
 	"github.com/alecthomas/kingpin"
+	"github.com/golang/protobuf/ptypes"
+	durpb "github.com/golang/protobuf/ptypes/duration"
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/statusfile"
+	"github.com/pauldub/zei/pkg/zeidapi"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/twitchtv/twirp"
 )
 
 var (
-	app        = kingpin.New("zei", "A ZEI Timeular command line client.")
-	apiAddress = app.Flag("api", "Address to the API server.").Default("http://localhost:8594").String()
+	app            = kingpin.New("zei", "A ZEI Timeular command line client.")
+	apiAddress     = app.Flag("api", "Address to the API server, an HTTP URL or a unix:// socket path.").Envar("ZEI_API").Default(zeidapi.DefaultAddress()).String()
+	tokenFile      = app.Flag("token-file", "File holding the token of the API server.").Envar("ZEI_TOKEN_FILE").Default(zeidapi.DefaultTokenPath()).String()
+	tlsCA          = app.Flag("tls-ca", "PEM CA verifying the certificate of an https API server, instead of the system CAs.").Envar("ZEI_TLS_CA").ExistingFile()
+	tlsCert        = app.Flag("tls-cert", "PEM client certificate presented to an https API server.").Envar("ZEI_TLS_CERT").ExistingFile()
+	tlsKey         = app.Flag("tls-key", "PEM key of the client certificate.").Envar("ZEI_TLS_KEY").ExistingFile()
+	device         = app.Flag("device", "Serial number of the device, may be omitted while a single device is connected.").Envar("ZEI_DEVICE").String()
+	timezone       = app.Flag("timezone", "Time zone used to display times, e.g. Europe/Paris (default: local time zone).").Default("Local").String()
+	output         = app.Flag("output", "Output format, one of text, json, yaml or template.").Short('o').Default("text").Enum("text", "json", "yaml", "template")
+	outputTemplate = app.Flag("template", "Go template executed with the output of the command, when the output format is template.").String()
+
+	status          = app.Command("status", "Prints Timeular status on stdout, exits with 0 when tracking and 2 when not, or always 0 with a status bar format.")
+	statusFormat    = status.Flag("format", "Format of the status, text uses the output format, or one of i3blocks, waybar, polybar and tmux.").Default("text").Enum("text", "i3blocks", "waybar", "polybar", "tmux")
+	statusMaxLength = status.Flag("max-length", "Maximum length of the activity name in status bar formats, 0 for no limit.").Default("20").Int()
+	statusCached    = status.Flag("cached", "Read the status from the file written by zeid instead of requesting it.").Bool()
+	statusFilePath  = status.Flag("status-file", "Status file written by zeid.").Default(statusfile.DefaultPath()).String()
+	statusMaxAge    = status.Flag("max-age", "Maximum age of the status file, older files are considered an error.").Default("1m").Duration()
 
-	status         = app.Command("status", "Prints Timeular status on stdout.")
 	listActivities = app.Command("activities", "List Timeular activities.")
 
-	assignActivity   = app.Command("assign", "Assigns an activity to a device side.")
-	assignActivityID = assignActivity.Flag("id", "The ID of the activity to assign.").Required().String()
+	listDevices = app.Command("devices", "Lists the devices managed by zeid.")
+	deviceInfo  = app.Command("device", "Prints the information and battery level of the device.")
+	doctor      = app.Command("doctor", "Diagnoses the common problems preventing tracking, exits with 1 when one is found.")
+
+	assignActivity     = app.Command("assign", "Assigns an activity to a device side, the side on top of the device by default.")
+	assignActivityID   = assignActivity.Flag("id", "The ID of the activity to assign.").String()
+	assignActivityName = assignActivity.Flag("name", "The name of the activity to assign, or an unambiguous part of it.").String()
+	assignActivitySide = assignActivity.Flag("side", "The side to assign the activity to, from 1 to 8.").Int64()
+
+	listSides = app.Command("sides", "Lists the activities assigned to each device side.")
+
+	layout            = app.Command("layout", "Manages the activities of all the device sides at once.")
+	applyLayout       = layout.Command("apply", "Reconciles the device sides with a layout file, creating missing activities and unassigning undeclared sides.")
+	applyLayoutFile   = applyLayout.Arg("file", "The YAML layout file.").Required().ExistingFile()
+	applyLayoutDryRun = applyLayout.Flag("dry-run", "Prints the changes without applying them.").Bool()
+	exportLayout      = layout.Command("export", "Prints the layout of the device sides as YAML.")
+
+	profile           = app.Command("profile", "Manages the profiles of the device, each with its own layout.")
+	listProfiles      = profile.Command("list", "Lists the profiles and the active one.").Default()
+	switchProfile     = profile.Command("switch", "Makes a profile active, applying its layout.")
+	switchProfileName = switchProfile.Arg("name", "The name of the profile.").Required().String()
+
+	startActivity   = app.Command("start", "Starts tracking an activity.").Alias("switch")
+	startActivityID = startActivity.Arg("activity", "The ID or name of the activity to track.").Required().String()
+	stopActivity    = app.Command("stop", "Stops tracking the current activity.")
+
+	completion      = app.Command("completion", "Prints the completion script of a shell.")
+	completionShell = completion.Arg("shell", "The shell, one of bash, zsh or fish.").Required().Enum("bash", "zsh", "fish")
+
+	reminders            = app.Command("reminders", "Manages reminders of forgotten tracking.")
+	showReminders        = reminders.Command("show", "Prints the reminder rules.").Default()
+	updateReminders      = reminders.Command("set", "Updates the reminder rules, a zero duration or 'off' disables a rule.")
+	updateRemindAfter    = updateReminders.Flag("remind-after", "Notify after an activity has been tracked continuously for this long.").String()
+	updateEndOfDay       = updateReminders.Flag("end-of-day", "Time of day at which tracking is stopped, e.g. 19:00.").String()
+	updateWorkHours      = updateReminders.Flag("work-hours", "Working hours of week days, e.g. 09:00-18:00.").String()
+	updateUntrackedAfter = updateReminders.Flag("untracked-after", "Warn when nothing has been tracked for this long during working hours.").String()
+
+	idleGap        = app.Command("idle", "Resolves the time not tracked while the session was idle.")
+	idleGapKeep    = idleGap.Command("keep", "Tracks the idle time on the stopped activity.")
+	idleGapDiscard = idleGap.Command("discard", "Drops the idle time.")
+
+	clk = clock.Real
 )
 
 func main() {
+	if newCompletion().Complete() {
+		os.Exit(0)
+	}
+
 	command, err := app.Parse(os.Args[1:])
-	if err != nil {
-		log.Printf("failed to parse arguments: %+v", err)
-		os.Exit(1)
+	logError("failed to parse arguments", err)
+
+	if *output == "template" && *outputTemplate == "" {
+		logError("invalid output", fmt.Errorf("the template output requires a --template"))
 	}
 
-	client := zeid.NewZeiProtobufClient(*apiAddress, &http.Client{})
+	location, err := time.LoadLocation(*timezone)
+	logError("failed to load time zone", err)
 
 	switch kingpin.MustParse(command, err) {
 	case status.FullCommand():
 		ctx := context.Background()
 
-		currentActivity, err := client.CurrentActivity(ctx, &zeid.CurrentActivityReq{})
-		logError("failed to request current activity", err)
+		var currentActivity *zeid.CurrentActivityResp
+		if *statusCached {
+			currentActivity, err = readCachedStatus(statusfile.DevicePath(*statusFilePath, *device), *statusMaxAge)
+			logError("failed to read status file", err)
+		} else {
+			currentActivity, err = apiClient().CurrentActivity(ctx, &zeid.CurrentActivityReq{Device: *device})
+			logError("failed to request current activity", err)
+		}
+
+		if *statusFormat != "text" {
+			bar, err := newStatusBar(location, currentActivity, *statusMaxLength)
+			logError("failed to format status", err)
 
-		startTime, err := time.Parse(time.RFC3339, currentActivity.StartTime)
-		logError("failed to parse startTime", err)
+			status, err := formatStatusBar(*statusFormat, bar)
+			logError("failed to format status", err)
 
-		if currentActivity.IsIdle {
-			fmt.Println("Not tracking anything!")
+			// status bars consider other exit codes as failures, idleness is
+			// part of the output.
+			fmt.Println(status)
 			os.Exit(0)
 		}
 
-		fmt.Printf("Tracking %s since %s\n", currentActivity.Activity.Name, time.Since(startTime).Truncate(time.Second).String())
+		exitCode := exitTracking
+		if currentActivity.IsIdle {
+			exitCode = exitIdle
+		}
+
+		status, err := formatStatus(location, currentActivity)
+		logError("failed to format status", err)
+
+		out, err := newStatusOutput(currentActivity)
+		logError("failed to format status", err)
+
+		printOutput(out, status)
+		os.Exit(exitCode)
+	case listDevices.FullCommand():
+		ctx := context.Background()
+		client := apiClient()
+
+		res, err := client.ListDevices(ctx, &zeid.ListDevicesReq{})
+		logError("failed to request devices", err)
+
+		out := devicesOutput{Devices: make([]deviceOutput, 0, len(res.Devices))}
+		text := []string{"Devices:"}
+
+		for _, d := range res.Devices {
+			device := deviceOutput{
+				Serial:    d.Serial,
+				Connected: d.Connected,
+				Profile:   d.Profile,
+				Tracking:  !d.IsIdle,
+			}
+			if !d.IsIdle {
+				device.Activity = newActivityOutput(d.Activity)
+			}
+			out.Devices = append(out.Devices, device)
+
+			line := fmt.Sprintf("%s - %s (%s)", d.Serial, d.Activity.GetName(), d.Profile)
+			if d.IsIdle {
+				line = fmt.Sprintf("%s - not tracking (%s)", d.Serial, d.Profile)
+			}
+			if !d.Connected {
+				line = highlight(line + " disconnected")
+			}
+
+			text = append(text, line)
+		}
+
+		printOutput(out, strings.Join(text, "\n"))
+		os.Exit(0)
+	case deviceInfo.FullCommand():
+		ctx := context.Background()
+		client := apiClient()
+
+		res, err := client.DeviceInfo(ctx, &zeid.DeviceInfoReq{Device: *device})
+		logError("failed to request device information", err)
+
+		out, text := newDeviceInfoOutput(res)
+		printOutput(out, text)
+		os.Exit(0)
+	case doctor.FullCommand():
+		out := runDoctor(context.Background(), apiClient(), location)
+		printOutput(out, out.String())
+
+		if !out.Healthy {
+			os.Exit(exitError)
+		}
 		os.Exit(0)
 	case listActivities.FullCommand():
 		ctx := context.Background()
+		client := apiClient()
 
-		res, err := client.ListActivities(ctx, &zeid.ListActivitiesReq{})
+		res, err := client.ListActivities(ctx, &zeid.ListActivitiesReq{Device: *device})
 		logError("failed to request activities", err)
 
-		fmt.Println("Activities:")
+		out := activitiesOutput{
+			Activities:        make([]activityOutput, 0, len(res.Activities)),
+			CurrentActivityID: res.CurrentActivityId,
+		}
+		text := []string{"Activities:"}
 
 		for _, a := range res.Activities {
+			out.Activities = append(out.Activities, *newActivityOutput(a))
+
 			if res.CurrentActivityId == a.Id {
-				fmt.Printf("%s - %s (on top)\n", a.Id, a.Name)
+				text = append(text, fmt.Sprintf("%s - %s (on top)", a.Id, a.Name))
 			} else {
-				fmt.Printf("%s - %s\n", a.Id, a.Name)
+				text = append(text, fmt.Sprintf("%s - %s", a.Id, a.Name))
 			}
 		}
 
+		printOutput(out, strings.Join(text, "\n"))
 		os.Exit(0)
 	case assignActivity.FullCommand():
 		ctx := context.Background()
 
-		_, err := client.AssignActivity(ctx, &zeid.AssignActivityReq{
-			ActivityId: *assignActivityID,
+		if *assignActivityID == "" && *assignActivityName == "" {
+			logError("failed to assign activity", fmt.Errorf("--id or --name is required"))
+		}
+
+		client := apiClient()
+
+		res, err := client.AssignActivity(ctx, &zeid.AssignActivityReq{
+			Device:       *device,
+			ActivityId:   *assignActivityID,
+			ActivityName: *assignActivityName,
+			Side:         *assignActivitySide,
 		})
 		logError("failed to assign activity", err)
 
-		fmt.Printf("Activity %s was succesfully assigned!\n", *assignActivityID)
+		printOutput(
+			assignOutput{
+				ActivityID: res.Activity.GetId(),
+				Activity:   newActivityOutput(res.Activity),
+				Side:       res.Side,
+			},
+			fmt.Sprintf("Activity %s was succesfully assigned to side %d!", res.Activity.GetName(), res.Side),
+		)
+		os.Exit(0)
+	case listSides.FullCommand():
+		ctx := context.Background()
+		client := apiClient()
+
+		res, err := client.ListSides(ctx, &zeid.ListSidesReq{Device: *device})
+		logError("failed to request sides", err)
+
+		out := sidesOutput{
+			Sides:       make([]sideOutput, 0, len(res.Sides)),
+			CurrentSide: res.CurrentSide,
+		}
+		text := []string{"Sides:"}
+
+		for _, side := range res.Sides {
+			out.Sides = append(out.Sides, sideOutput{
+				Side:     side.Number,
+				Activity: newActivityOutput(side.Activity),
+			})
+
+			line := fmt.Sprintf("%d - %s", side.Number, highlight("(empty)"))
+			if side.Activity != nil {
+				line = fmt.Sprintf("%d - %s", side.Number, side.Activity.Name)
+			}
+			if side.Number == res.CurrentSide {
+				line += " (on top)"
+			}
+
+			text = append(text, line)
+		}
+
+		printOutput(out, strings.Join(text, "\n"))
+		os.Exit(0)
+	case applyLayout.FullCommand():
+		ctx := context.Background()
+		client := apiClient()
+
+		sides, err := readLayout(*applyLayoutFile)
+		logError("failed to read layout", err)
+
+		res, err := client.ApplyLayout(ctx, &zeid.ApplyLayoutReq{
+			Device: *device,
+			Sides:  sides,
+			DryRun: *applyLayoutDryRun,
+		})
+		logError("failed to apply layout", err)
+
+		out, text := newApplyLayoutOutput(res)
+		printOutput(out, text)
+		os.Exit(0)
+	case exportLayout.FullCommand():
+		ctx := context.Background()
+		client := apiClient()
+
+		res, err := client.ListSides(ctx, &zeid.ListSidesReq{Device: *device})
+		logError("failed to request sides", err)
+
+		out, text, err := newLayoutFileOutput(res)
+		logError("failed to export layout", err)
+
+		printOutput(out, text)
+		os.Exit(0)
+	case listProfiles.FullCommand():
+		ctx := context.Background()
+		client := apiClient()
+
+		res, err := client.ListProfiles(ctx, &zeid.ListProfilesReq{Device: *device})
+		logError("failed to request profiles", err)
+
+		out, text := newProfilesOutput(res)
+		printOutput(out, text)
+		os.Exit(0)
+	case switchProfile.FullCommand():
+		ctx := context.Background()
+		client := apiClient()
+
+		res, err := client.SwitchProfile(ctx, &zeid.SwitchProfileReq{
+			Device: *device,
+			Name:   *switchProfileName,
+		})
+		logError("failed to switch profile", err)
+
+		out, text := newSwitchProfileOutput(res)
+		printOutput(out, text)
+		os.Exit(0)
+	case startActivity.FullCommand():
+		ctx := context.Background()
+		client := apiClient()
+
+		res, err := startActivityByIDOrName(ctx, client, *startActivityID)
+		logError("failed to start activity", err)
+
+		printOutput(
+			trackingOutput{Tracking: true, Activity: newActivityOutput(res.Activity)},
+			fmt.Sprintf("Tracking %s", res.Activity.Name),
+		)
+		os.Exit(0)
+	case stopActivity.FullCommand():
+		ctx := context.Background()
+		client := apiClient()
+
+		res, err := client.StopActivity(ctx, &zeid.StopActivityReq{Device: *device})
+		logError("failed to stop activity", err)
+
+		text := fmt.Sprintf("Stopped tracking %s", res.Activity.Name)
+		if res.Activity.Name == "Idle" {
+			text = "Not tracking anything!"
+		}
+
+		printOutput(trackingOutput{Tracking: false}, text)
+		os.Exit(0)
+	case completion.FullCommand():
+		script, err := completionScript(*completionShell)
+		logError("failed to generate completion script", err)
+
+		fmt.Print(script)
+		os.Exit(0)
+	case showReminders.FullCommand():
+		ctx := context.Background()
+		client := apiClient()
+
+		res, err := client.GetReminderRules(ctx, &zeid.GetReminderRulesReq{Device: *device})
+		logError("failed to request reminder rules", err)
+
+		out := newReminderRulesOutput(res.Rules)
+		printOutput(out, out.String())
+		os.Exit(0)
+	case updateReminders.FullCommand():
+		ctx := context.Background()
+		client := apiClient()
+
+		res, err := client.GetReminderRules(ctx, &zeid.GetReminderRulesReq{Device: *device})
+		logError("failed to request reminder rules", err)
+
+		rules := res.Rules
+		if rules == nil {
+			rules = &zeid.ReminderRules{}
+		}
+
+		err = updateReminderRules(rules)
+		logError("failed to parse reminder rules", err)
+
+		updated, err := client.UpdateReminderRules(ctx, &zeid.UpdateReminderRulesReq{Device: *device, Rules: rules})
+		logError("failed to update reminder rules", err)
+
+		out := newReminderRulesOutput(updated.Rules)
+		printOutput(out, out.String())
+		os.Exit(0)
+	case idleGapKeep.FullCommand(), idleGapDiscard.FullCommand():
+		ctx := context.Background()
+		client := apiClient()
+
+		res, err := client.ResolveIdleGap(ctx, &zeid.ResolveIdleGapReq{
+			Device: *device,
+			Keep:   command == idleGapKeep.FullCommand(),
+		})
+		logError("failed to resolve idle gap", err)
+
+		from, err := ptypes.Timestamp(res.From)
+		logError("failed to parse idle gap start", err)
+
+		to, err := ptypes.Timestamp(res.To)
+		logError("failed to parse idle gap end", err)
+
+		action := "discarded"
+		if res.Kept {
+			action = "tracked"
+		}
+
+		printOutput(
+			idleGapOutput{
+				Activity: newActivityOutput(res.Activity),
+				From:     from,
+				To:       to,
+				Kept:     res.Kept,
+			},
+			fmt.Sprintf(
+				"Idle time of %s from %s to %s was %s.",
+				res.Activity.Name,
+				formatTime(location, from),
+				formatTime(location, to),
+				action,
+			),
+		)
 		os.Exit(0)
 	}
 }
 
-func logError(message string, err error) {
+func formatStatus(location *time.Location, currentActivity *zeid.CurrentActivityResp) (string, error) {
+	if currentActivity.IsIdle {
+		return "Not tracking anything!", nil
+	}
+
+	startTime, err := ptypes.Timestamp(currentActivity.StartTime)
+	if err != nil {
+		return "", err
+	}
+
+	status := fmt.Sprintf(
+		"Tracking %s since %s (started at %s)",
+		currentActivity.Activity.Name,
+		clk.Since(startTime).Truncate(time.Second).String(),
+		formatTime(location, startTime),
+	)
+
+	if focus := currentActivity.Focus; focus != nil {
+		remaining, err := ptypes.Duration(focus.Remaining)
+		if err != nil {
+			return "", err
+		}
+
+		phase := "Focus"
+		if focus.Phase == zeid.FocusPhase_FOCUS_BREAK {
+			phase = "Break"
+		}
+
+		cycle := fmt.Sprintf("cycle %d", focus.Cycle)
+		if focus.Cycles > 0 {
+			cycle = fmt.Sprintf("cycle %d/%d", focus.Cycle, focus.Cycles)
+		}
+
+		status += fmt.Sprintf("\n%s, %s left (%s)", phase, remaining.Truncate(time.Second).String(), cycle)
+	}
+
+	return status, nil
+}
+
+func updateReminderRules(rules *zeid.ReminderRules) error {
+	var err error
+
+	if *updateRemindAfter != "" {
+		rules.LongTracking, err = parseDurationRule(*updateRemindAfter)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *updateUntrackedAfter != "" {
+		rules.UntrackedAfter, err = parseDurationRule(*updateUntrackedAfter)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *updateEndOfDay != "" {
+		rules.EndOfDay, err = parseTimeOfDayRule(*updateEndOfDay)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *updateWorkHours != "" {
+		rules.WorkStart, rules.WorkEnd = nil, nil
+
+		if *updateWorkHours != "off" {
+			hours := strings.SplitN(*updateWorkHours, "-", 2)
+			if len(hours) != 2 {
+				return fmt.Errorf("invalid working hours %q, expected HH:MM-HH:MM", *updateWorkHours)
+			}
+
+			rules.WorkStart, err = parseTimeOfDayRule(hours[0])
+			if err != nil {
+				return err
+			}
+
+			rules.WorkEnd, err = parseTimeOfDayRule(hours[1])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseDurationRule(value string) (*durpb.Duration, error) {
+	if value == "off" {
+		return nil, nil
+	}
+
+	d, err := time.ParseDuration(value)
 	if err != nil {
+		return nil, err
+	}
+
+	return ptypes.DurationProto(d), nil
+}
+
+func parseTimeOfDayRule(value string) (*durpb.Duration, error) {
+	if value == "off" {
+		return nil, nil
+	}
+
+	d, err := clock.ParseTimeOfDay(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return ptypes.DurationProto(d), nil
+}
+
+// startActivityByIDOrName starts the activity with the given ID, or else
+// the one zeid matches with the given name.
+func startActivityByIDOrName(ctx context.Context, client zeid.Zei, idOrName string) (*zeid.StartActivityResp, error) {
+	res, err := client.StartActivity(ctx, &zeid.StartActivityReq{
+		Device:     *device,
+		ActivityId: idOrName,
+	})
+	if twerr, ok := err.(twirp.Error); !ok || twerr.Code() != twirp.NotFound {
+		return res, err
+	}
+
+	return client.StartActivity(ctx, &zeid.StartActivityReq{
+		Device:       *device,
+		ActivityName: idOrName,
+	})
+}
+
+// apiClient returns the client of the API server configured by the flags,
+// it exits when the client can't be created.
+func apiClient() zeid.Zei {
+	client, err := newClient(*apiAddress, *tokenFile, zeidapi.ClientOptions{
+		CAFile:   *tlsCA,
+		CertFile: *tlsCert,
+		KeyFile:  *tlsKey,
+	})
+	logError("failed to create API client", err)
+
+	return client
+}
+
+// newClient returns a client of the API server at address, authenticated
+// with the token held by tokenFile when it exists.
+func newClient(address, tokenFile string, opts zeidapi.ClientOptions) (zeid.Zei, error) {
+	token, err := zeidapi.ReadToken(tokenFile)
+	if err != nil {
+		return nil, err
+	}
+	opts.Token = token
+
+	baseURL, httpClient, err := zeidapi.NewHTTPClient(address, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return zeid.NewZeiProtobufClient(baseURL, httpClient), nil
+}
+
+// formatTime renders t in the given location, the date is omitted
+// when t is on the current day.
+func formatTime(location *time.Location, t time.Time) string {
+	t = t.In(location)
+
+	ny, nm, nd := clk.Now().In(location).Date()
+	if y, m, d := t.Date(); y == ny && m == nm && d == nd {
+		return t.Format("15:04 MST")
+	}
+
+	return t.Format("2006-01-02 15:04 MST")
+}
+
+// logError exits when err is not nil, after printing it as an error object
+// in the json and yaml output formats or logging it otherwise.
+func logError(message string, err error) {
+	if err == nil {
+		return
+	}
+
+	switch *output {
+	case "json", "yaml":
+		printError(newErrorOutput(message, err))
+	default:
 		log.Printf("%s: %+v", message, err)
-		os.Exit(1)
 	}
+
+	os.Exit(exitError)
 }
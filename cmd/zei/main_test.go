@@ -0,0 +1,233 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// dstCases start an activity before a daylight saving time transition and
+// format it after.
+var dstCases = []struct {
+	name     string
+	zone     string
+	start    time.Time
+	now      time.Time
+	status   string
+	elapsed  time.Duration
+	barLabel string
+	tooltip  string
+}{
+	{
+		name:     "spring forward",
+		zone:     "Europe/Paris",
+		start:    time.Date(2019, time.March, 31, 0, 30, 0, 0, time.UTC),
+		now:      time.Date(2019, time.March, 31, 1, 30, 0, 0, time.UTC),
+		status:   "Tracking Coding since 1h0m0s (started at 01:30 CET)",
+		elapsed:  time.Hour,
+		barLabel: "Coding 1:00",
+		tooltip:  "Coding since 01:30",
+	},
+	{
+		name:     "fall back",
+		zone:     "Europe/Paris",
+		start:    time.Date(2019, time.October, 27, 0, 30, 0, 0, time.UTC),
+		now:      time.Date(2019, time.October, 27, 1, 30, 0, 0, time.UTC),
+		status:   "Tracking Coding since 1h0m0s (started at 02:30 CEST)",
+		elapsed:  time.Hour,
+		barLabel: "Coding 1:00",
+		tooltip:  "Coding since 02:30",
+	},
+	{
+		name:     "fall back from the previous day",
+		zone:     "Europe/Paris",
+		start:    time.Date(2019, time.October, 26, 21, 0, 0, 0, time.UTC),
+		now:      time.Date(2019, time.October, 27, 9, 15, 0, 0, time.UTC),
+		status:   "Tracking Coding since 12h15m0s (started at 2019-10-26 23:00 CEST)",
+		elapsed:  12*time.Hour + 15*time.Minute,
+		barLabel: "Coding 12:15",
+		tooltip:  "Coding since 23:00",
+	},
+	{
+		name:     "spring forward west of UTC",
+		zone:     "America/New_York",
+		start:    time.Date(2019, time.March, 10, 6, 45, 0, 0, time.UTC),
+		now:      time.Date(2019, time.March, 10, 7, 5, 0, 0, time.UTC),
+		status:   "Tracking Coding since 20m0s (started at 01:45 EST)",
+		elapsed:  20 * time.Minute,
+		barLabel: "Coding 20m",
+		tooltip:  "Coding since 01:45",
+	},
+	{
+		name:     "fall back west of UTC",
+		zone:     "America/New_York",
+		start:    time.Date(2019, time.November, 3, 5, 30, 0, 0, time.UTC),
+		now:      time.Date(2019, time.November, 3, 6, 30, 0, 0, time.UTC),
+		status:   "Tracking Coding since 1h0m0s (started at 01:30 EDT)",
+		elapsed:  time.Hour,
+		barLabel: "Coding 1:00",
+		tooltip:  "Coding since 01:30",
+	},
+}
+
+func loadLocation(t *testing.T, zone string) *time.Location {
+	t.Helper()
+
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		t.Skipf("time zone %s is not available: %v", zone, err)
+	}
+
+	return location
+}
+
+// useClock sets the clock of the CLI at now until the returned function is
+// called.
+func useClock(now time.Time) func() {
+	previous := clk
+	clk = clock.NewFake(now)
+
+	return func() {
+		clk = previous
+	}
+}
+
+func trackingSince(t *testing.T, start time.Time) *zeid.CurrentActivityResp {
+	t.Helper()
+
+	startTime, err := ptypes.TimestampProto(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &zeid.CurrentActivityResp{
+		Activity:  &zeid.Activity{Id: "1", Name: "Coding"},
+		StartTime: startTime,
+	}
+}
+
+func TestFormatStatusDST(t *testing.T) {
+	for _, c := range dstCases {
+		t.Run(c.name, func(t *testing.T) {
+			location := loadLocation(t, c.zone)
+			defer useClock(c.now)()
+
+			status, err := formatStatus(location, trackingSince(t, c.start))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if status != c.status {
+				t.Errorf("got %q, want %q", status, c.status)
+			}
+		})
+	}
+}
+
+func TestStatusOutputDST(t *testing.T) {
+	for _, c := range dstCases {
+		t.Run(c.name, func(t *testing.T) {
+			defer useClock(c.now)()
+
+			out, err := newStatusOutput(trackingSince(t, c.start))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !out.StartTime.Equal(c.start) {
+				t.Errorf("got start time %s, want %s", out.StartTime, c.start)
+			}
+			if out.Duration != c.elapsed.String() || out.DurationSeconds != int64(c.elapsed/time.Second) {
+				t.Errorf("got duration %s (%ds), want %s", out.Duration, out.DurationSeconds, c.elapsed)
+			}
+		})
+	}
+}
+
+func TestStatusBarDST(t *testing.T) {
+	for _, c := range dstCases {
+		t.Run(c.name, func(t *testing.T) {
+			location := loadLocation(t, c.zone)
+			defer useClock(c.now)()
+
+			bar, err := newStatusBar(location, trackingSince(t, c.start), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if bar.label != c.barLabel {
+				t.Errorf("got label %q, want %q", bar.label, c.barLabel)
+			}
+			if bar.tooltip != c.tooltip {
+				t.Errorf("got tooltip %q, want %q", bar.tooltip, c.tooltip)
+			}
+		})
+	}
+}
+
+func TestFormatTimeDST(t *testing.T) {
+	cases := []struct {
+		name string
+		zone string
+		t    time.Time
+		now  time.Time
+		want string
+	}{
+		{
+			name: "before spring forward",
+			zone: "Europe/Paris",
+			t:    time.Date(2019, time.March, 31, 0, 59, 0, 0, time.UTC),
+			now:  time.Date(2019, time.March, 31, 12, 0, 0, 0, time.UTC),
+			want: "01:59 CET",
+		},
+		{
+			name: "after spring forward",
+			zone: "Europe/Paris",
+			t:    time.Date(2019, time.March, 31, 1, 0, 0, 0, time.UTC),
+			now:  time.Date(2019, time.March, 31, 12, 0, 0, 0, time.UTC),
+			want: "03:00 CEST",
+		},
+		{
+			name: "first 02:30 of fall back",
+			zone: "Europe/Paris",
+			t:    time.Date(2019, time.October, 27, 0, 30, 0, 0, time.UTC),
+			now:  time.Date(2019, time.October, 27, 12, 0, 0, 0, time.UTC),
+			want: "02:30 CEST",
+		},
+		{
+			name: "second 02:30 of fall back",
+			zone: "Europe/Paris",
+			t:    time.Date(2019, time.October, 27, 1, 30, 0, 0, time.UTC),
+			now:  time.Date(2019, time.October, 27, 12, 0, 0, 0, time.UTC),
+			want: "02:30 CET",
+		},
+		{
+			name: "day before fall back",
+			zone: "Europe/Paris",
+			t:    time.Date(2019, time.October, 26, 21, 59, 0, 0, time.UTC),
+			now:  time.Date(2019, time.October, 26, 22, 0, 0, 0, time.UTC),
+			want: "2019-10-26 23:59 CEST",
+		},
+		{
+			name: "same UTC day, next local day",
+			zone: "America/New_York",
+			t:    time.Date(2019, time.November, 3, 3, 0, 0, 0, time.UTC),
+			now:  time.Date(2019, time.November, 3, 6, 0, 0, 0, time.UTC),
+			want: "2019-11-02 23:00 EDT",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			location := loadLocation(t, c.zone)
+			defer useClock(c.now)()
+
+			if got := formatTime(location, c.t); got != c.want {
+				t.Errorf("got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/template"
+	"github.com/golang/protobuf/ptypes"
+	durpb "github.com/golang/protobuf/ptypes/duration"
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/twitchtv/twirp"
+	"gopkg.in/yaml.v2"
+)
+
+// Exit codes of the status command in the text format, errors always exit
+// with exitError.
+const (
+	exitTracking = 0
+	exitError    = 1
+	exitIdle     = 2
+)
+
+// Outputs of the commands, their field names are part of the command line
+// interface and must stay stable.
+type (
+	activityOutput struct {
+		ID          string `json:"id" yaml:"id"`
+		Name        string `json:"name" yaml:"name"`
+		Color       string `json:"color" yaml:"color"`
+		Integration string `json:"integration" yaml:"integration"`
+		DeviceSide  int64  `json:"device_side" yaml:"device_side"`
+	}
+
+	focusOutput struct {
+		Phase            string    `json:"phase" yaml:"phase"`
+		Cycle            int64     `json:"cycle" yaml:"cycle"`
+		Cycles           int64     `json:"cycles" yaml:"cycles"`
+		EndsAt           time.Time `json:"ends_at" yaml:"ends_at"`
+		Remaining        string    `json:"remaining" yaml:"remaining"`
+		RemainingSeconds int64     `json:"remaining_seconds" yaml:"remaining_seconds"`
+	}
+
+	statusOutput struct {
+		Tracking        bool            `json:"tracking" yaml:"tracking"`
+		Device          string          `json:"device,omitempty" yaml:"device,omitempty"`
+		Activity        *activityOutput `json:"activity,omitempty" yaml:"activity,omitempty"`
+		StartTime       *time.Time      `json:"start_time,omitempty" yaml:"start_time,omitempty"`
+		Duration        string          `json:"duration,omitempty" yaml:"duration,omitempty"`
+		DurationSeconds int64           `json:"duration_seconds" yaml:"duration_seconds"`
+		DeviceConnected bool            `json:"device_connected" yaml:"device_connected"`
+		Focus           *focusOutput    `json:"focus,omitempty" yaml:"focus,omitempty"`
+	}
+
+	activitiesOutput struct {
+		Activities        []activityOutput `json:"activities" yaml:"activities"`
+		CurrentActivityID string           `json:"current_activity_id" yaml:"current_activity_id"`
+	}
+
+	assignOutput struct {
+		ActivityID string          `json:"activity_id" yaml:"activity_id"`
+		Activity   *activityOutput `json:"activity" yaml:"activity"`
+		Side       int64           `json:"side" yaml:"side"`
+	}
+
+	sideOutput struct {
+		Side     int64           `json:"side" yaml:"side"`
+		Activity *activityOutput `json:"activity" yaml:"activity"`
+	}
+
+	sidesOutput struct {
+		Sides       []sideOutput `json:"sides" yaml:"sides"`
+		CurrentSide int64        `json:"current_side" yaml:"current_side"`
+	}
+
+	trackingOutput struct {
+		Tracking bool            `json:"tracking" yaml:"tracking"`
+		Activity *activityOutput `json:"activity,omitempty" yaml:"activity,omitempty"`
+	}
+
+	reminderRulesOutput struct {
+		RemindAfter    string `json:"remind_after" yaml:"remind_after"`
+		EndOfDay       string `json:"end_of_day" yaml:"end_of_day"`
+		WorkHours      string `json:"work_hours" yaml:"work_hours"`
+		UntrackedAfter string `json:"untracked_after" yaml:"untracked_after"`
+	}
+
+	deviceOutput struct {
+		Serial    string          `json:"serial" yaml:"serial"`
+		Connected bool            `json:"connected" yaml:"connected"`
+		Profile   string          `json:"profile" yaml:"profile"`
+		Tracking  bool            `json:"tracking" yaml:"tracking"`
+		Activity  *activityOutput `json:"activity,omitempty" yaml:"activity,omitempty"`
+	}
+
+	devicesOutput struct {
+		Devices []deviceOutput `json:"devices" yaml:"devices"`
+	}
+
+	deviceInfoOutput struct {
+		Serial           string `json:"serial" yaml:"serial"`
+		Connected        bool   `json:"connected" yaml:"connected"`
+		Manufacturer     string `json:"manufacturer" yaml:"manufacturer"`
+		Model            string `json:"model" yaml:"model"`
+		SerialNumber     string `json:"serial_number" yaml:"serial_number"`
+		HardwareRevision string `json:"hardware_revision" yaml:"hardware_revision"`
+		FirmwareRevision string `json:"firmware_revision" yaml:"firmware_revision"`
+		SoftwareRevision string `json:"software_revision" yaml:"software_revision"`
+		BatteryLevel     *int64 `json:"battery_level" yaml:"battery_level"`
+	}
+
+	errorOutput struct {
+		Error   string `json:"error" yaml:"error"`
+		Message string `json:"message" yaml:"message"`
+		// Code is the code of the errors returned by the API server.
+		Code string `json:"code,omitempty" yaml:"code,omitempty"`
+	}
+
+	idleGapOutput struct {
+		Activity *activityOutput `json:"activity" yaml:"activity"`
+		From     time.Time       `json:"from" yaml:"from"`
+		To       time.Time       `json:"to" yaml:"to"`
+		Kept     bool            `json:"kept" yaml:"kept"`
+	}
+)
+
+// printOutput prints v in the requested output format, text is printed by
+// the text output.
+func printOutput(v interface{}, text string) {
+	switch *output {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+
+		err := enc.Encode(v)
+		logError("failed to encode output", err)
+	case "yaml":
+		b, err := yaml.Marshal(v)
+		logError("failed to encode output", err)
+
+		_, err = os.Stdout.Write(b)
+		logError("failed to write output", err)
+	case "template":
+		tmpl, err := template.New("output").Parse(*outputTemplate)
+		logError("failed to parse template", err)
+
+		err = tmpl.Execute(os.Stdout, v)
+		logError("failed to execute template", err)
+
+		fmt.Println()
+	default:
+		fmt.Println(text)
+	}
+}
+
+func newErrorOutput(message string, err error) errorOutput {
+	out := errorOutput{
+		Error:   fmt.Sprintf("%s: %v", message, err),
+		Message: message,
+	}
+
+	if twerr, ok := err.(twirp.Error); ok {
+		out.Code = string(twerr.Code())
+	}
+
+	return out
+}
+
+// printError prints out in the requested output format, errors encoding it
+// are logged as logError would recurse.
+func printError(out errorOutput) {
+	var err error
+
+	switch *output {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+
+		err = enc.Encode(out)
+	case "yaml":
+		var b []byte
+		b, err = yaml.Marshal(out)
+		if err == nil {
+			_, err = os.Stdout.Write(b)
+		}
+	}
+
+	if err != nil {
+		log.Printf("%s (failed to print error: %v)", out.Error, err)
+	}
+}
+
+// highlight renders s in bold yellow when stdout is a terminal.
+func highlight(s string) string {
+	info, err := os.Stdout.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return s
+	}
+
+	return fmt.Sprintf("\x1b[1;33m%s\x1b[0m", s)
+}
+
+func newActivityOutput(a *zeid.Activity) *activityOutput {
+	if a == nil {
+		return nil
+	}
+
+	return &activityOutput{
+		ID:          a.Id,
+		Name:        a.Name,
+		Color:       a.Color,
+		Integration: a.Integration,
+		DeviceSide:  a.DeviceSide,
+	}
+}
+
+func newStatusOutput(currentActivity *zeid.CurrentActivityResp) (*statusOutput, error) {
+	out := &statusOutput{
+		Tracking:        !currentActivity.IsIdle,
+		Device:          currentActivity.Device,
+		DeviceConnected: currentActivity.DeviceConnected,
+	}
+
+	if currentActivity.IsIdle {
+		return out, nil
+	}
+
+	startTime, err := ptypes.Timestamp(currentActivity.StartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := clk.Since(startTime).Truncate(time.Second)
+
+	out.Activity = newActivityOutput(currentActivity.Activity)
+	out.StartTime = &startTime
+	out.Duration = duration.String()
+	out.DurationSeconds = int64(duration / time.Second)
+
+	if focus := currentActivity.Focus; focus != nil {
+		endsAt, err := ptypes.Timestamp(focus.EndsAt)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining, err := ptypes.Duration(focus.Remaining)
+		if err != nil {
+			return nil, err
+		}
+		remaining = remaining.Truncate(time.Second)
+
+		phase := "work"
+		if focus.Phase == zeid.FocusPhase_FOCUS_BREAK {
+			phase = "break"
+		}
+
+		out.Focus = &focusOutput{
+			Phase:            phase,
+			Cycle:            focus.Cycle,
+			Cycles:           focus.Cycles,
+			EndsAt:           endsAt,
+			Remaining:        remaining.String(),
+			RemainingSeconds: int64(remaining / time.Second),
+		}
+	}
+
+	return out, nil
+}
+
+func newReminderRulesOutput(rules *zeid.ReminderRules) reminderRulesOutput {
+	duration := func(pb *durpb.Duration) string {
+		d, err := ptypes.Duration(pb)
+		if err != nil || d == 0 {
+			return "off"
+		}
+		return d.String()
+	}
+	timeOfDay := func(pb *durpb.Duration) string {
+		d, err := ptypes.Duration(pb)
+		if err != nil {
+			return "off"
+		}
+		return clock.FormatTimeOfDay(d)
+	}
+
+	workHours := "off"
+	if start, end := timeOfDay(rules.GetWorkStart()), timeOfDay(rules.GetWorkEnd()); start != "off" && end != "off" {
+		workHours = fmt.Sprintf("%s-%s", start, end)
+	}
+
+	return reminderRulesOutput{
+		RemindAfter:    duration(rules.GetLongTracking()),
+		EndOfDay:       timeOfDay(rules.GetEndOfDay()),
+		WorkHours:      workHours,
+		UntrackedAfter: duration(rules.GetUntrackedAfter()),
+	}
+}
+
+func (r reminderRulesOutput) String() string {
+	return strings.Join([]string{
+		"Reminders:",
+		fmt.Sprintf("remind after: %s", r.RemindAfter),
+		fmt.Sprintf("end of day: %s", r.EndOfDay),
+		fmt.Sprintf("work hours: %s", r.WorkHours),
+		fmt.Sprintf("untracked after: %s", r.UntrackedAfter),
+	}, "\n")
+}
+
+// lowBatteryLevel is the battery level highlighted by the device command.
+const lowBatteryLevel = 20
+
+func newDeviceInfoOutput(res *zeid.DeviceInfoResp) (deviceInfoOutput, string) {
+	out := deviceInfoOutput{
+		Serial:           res.Serial,
+		Connected:        res.Connected,
+		Manufacturer:     res.Manufacturer,
+		Model:            res.Model,
+		SerialNumber:     res.SerialNumber,
+		HardwareRevision: res.HardwareRevision,
+		FirmwareRevision: res.FirmwareRevision,
+		SoftwareRevision: res.SoftwareRevision,
+	}
+
+	state := "connected"
+	if !res.Connected {
+		state = highlight("disconnected")
+	}
+	text := []string{fmt.Sprintf("Device %s (%s)", res.Serial, state)}
+
+	for _, field := range []struct{ name, value string }{
+		{"manufacturer", res.Manufacturer},
+		{"model", res.Model},
+		{"serial number", res.SerialNumber},
+		{"hardware revision", res.HardwareRevision},
+		{"firmware revision", res.FirmwareRevision},
+		{"software revision", res.SoftwareRevision},
+	} {
+		if field.value != "" {
+			text = append(text, fmt.Sprintf("%s: %s", field.name, field.value))
+		}
+	}
+
+	if res.HasBattery {
+		out.BatteryLevel = &res.BatteryLevel
+
+		battery := fmt.Sprintf("battery: %d%%", res.BatteryLevel)
+		if res.BatteryLevel <= lowBatteryLevel {
+			battery = highlight(battery + " (low)")
+		}
+		text = append(text, battery)
+	}
+
+	return out, strings.Join(text, "\n")
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pauldub/zei/pkg/statusfile"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/pkg/errors"
+)
+
+// statusBar is the status rendered for status bars and prompts.
+type statusBar struct {
+	label   string
+	short   string
+	color   string
+	class   string
+	tooltip string
+}
+
+func newStatusBar(location *time.Location, currentActivity *zeid.CurrentActivityResp, maxLength int) (statusBar, error) {
+	if currentActivity.IsIdle && !currentActivity.DeviceConnected {
+		return statusBar{
+			label:   "Disconnected",
+			short:   "disconnected",
+			class:   "disconnected",
+			tooltip: "The device is disconnected",
+		}, nil
+	}
+
+	if currentActivity.IsIdle {
+		return statusBar{
+			label:   "Not tracking",
+			short:   "idle",
+			class:   "idle",
+			tooltip: "Not tracking anything",
+		}, nil
+	}
+
+	startTime, err := ptypes.Timestamp(currentActivity.StartTime)
+	if err != nil {
+		return statusBar{}, err
+	}
+
+	var (
+		name    = currentActivity.Activity.GetName()
+		elapsed = shortDuration(clk.Since(startTime))
+		bar     = statusBar{
+			short:   truncate(name, maxLength),
+			color:   currentActivity.Activity.GetColor(),
+			class:   "tracking",
+			tooltip: fmt.Sprintf("%s since %s", name, startTime.In(location).Format("15:04")),
+		}
+	)
+
+	bar.label = fmt.Sprintf("%s %s", bar.short, elapsed)
+
+	if focus := currentActivity.Focus; focus != nil {
+		endsAt, err := ptypes.Timestamp(focus.EndsAt)
+		if err != nil {
+			return statusBar{}, err
+		}
+
+		bar.class = "focus"
+		if focus.Phase == zeid.FocusPhase_FOCUS_BREAK {
+			bar.class = "break"
+		}
+
+		bar.label = fmt.Sprintf("%s (%s %s)", bar.label, bar.class, shortDuration(endsAt.Sub(clk.Now())))
+	}
+
+	return bar, nil
+}
+
+// formatStatusBar renders the status in the format of a status bar.
+func formatStatusBar(format string, bar statusBar) (string, error) {
+	switch format {
+	case "i3blocks":
+		// full text, short text and color lines.
+		return strings.Join([]string{bar.label, bar.short, bar.color}, "\n"), nil
+	case "waybar":
+		// text and tooltip are Pango markup.
+		text := html.EscapeString(bar.label)
+		if bar.color != "" {
+			text = fmt.Sprintf("<span color='%s'>●</span> %s", html.EscapeString(bar.color), text)
+		}
+
+		b, err := json.Marshal(struct {
+			Text    string `json:"text"`
+			Alt     string `json:"alt"`
+			Tooltip string `json:"tooltip"`
+			Class   string `json:"class"`
+		}{text, bar.short, html.EscapeString(bar.tooltip), bar.class})
+
+		return string(b), err
+	case "polybar":
+		// % starts a formatting tag.
+		label := strings.Replace(bar.label, "%", "%%", -1)
+		if bar.color == "" {
+			return label, nil
+		}
+		return fmt.Sprintf("%%{F%s}●%%{F-} %s", bar.color, label), nil
+	case "tmux":
+		// # starts a style or a format.
+		label := strings.Replace(bar.label, "#", "##", -1)
+		if bar.color == "" {
+			return label, nil
+		}
+		return fmt.Sprintf("#[fg=%s]●#[default] %s", bar.color, label), nil
+	}
+
+	return "", errors.Errorf("unknown status format %q", format)
+}
+
+// readCachedStatus returns the current activity written by zeid to the
+// status file, it fails when the file is older than maxAge. zeid removes the
+// file once the device disconnected, a missing file is a disconnected device.
+func readCachedStatus(path string, maxAge time.Duration) (*zeid.CurrentActivityResp, error) {
+	status, modTime, err := statusfile.Read(path)
+	if os.IsNotExist(err) {
+		return &zeid.CurrentActivityResp{IsIdle: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if maxAge > 0 && clk.Since(modTime) > maxAge {
+		return nil, errors.Errorf("status file was last written at %s, is zeid running?", modTime.Format(time.RFC3339))
+	}
+
+	// durations were computed when the file was written.
+	if startTime, err := ptypes.Timestamp(status.StartTime); err == nil {
+		status.Duration = ptypes.DurationProto(clk.Since(startTime))
+	}
+
+	if status.Focus != nil {
+		if endsAt, err := ptypes.Timestamp(status.Focus.EndsAt); err == nil {
+			remaining := endsAt.Sub(clk.Now())
+			if remaining < 0 {
+				remaining = 0
+			}
+			status.Focus.Remaining = ptypes.DurationProto(remaining)
+		}
+	}
+
+	return status, nil
+}
+
+// shortDuration formats d as hours and minutes, or minutes under an hour.
+func shortDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+
+	return fmt.Sprintf("%d:%02d", d/time.Hour, (d%time.Hour)/time.Minute)
+}
+
+// truncate shortens s to at most max characters, unless max is zero.
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if max <= 0 || len(r) <= max {
+		return s
+	}
+
+	return string(r[:max-1]) + "…"
+}
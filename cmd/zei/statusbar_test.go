@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pauldub/zei/pkg/statusfile"
+	"github.com/pauldub/zei/rpc/zeid"
+)
+
+func TestFormatStatusBar(t *testing.T) {
+	bar := statusBar{
+		label:   "R&D <zei> 1:05",
+		short:   "R&D <zei>",
+		color:   "#ff0000",
+		class:   "tracking",
+		tooltip: "R&D <zei> since 09:00",
+	}
+
+	cases := []struct {
+		format string
+		bar    statusBar
+		want   string
+	}{
+		{"i3blocks", bar, "R&D <zei> 1:05\nR&D <zei>\n#ff0000"},
+		{"waybar", bar, `{"text":"<span color='#ff0000'>●</span> R&amp;D &lt;zei&gt; 1:05","alt":"R&D <zei>","tooltip":"R&amp;D &lt;zei&gt; since 09:00","class":"tracking"}`},
+		{"waybar", statusBar{label: "Not tracking", short: "idle", class: "idle", tooltip: "Not tracking anything"}, `{"text":"Not tracking","alt":"idle","tooltip":"Not tracking anything","class":"idle"}`},
+		{"polybar", bar, "%{F#ff0000}●%{F-} R&D <zei> 1:05"},
+		{"polybar", statusBar{label: "100% #1 1:05"}, "100%% #1 1:05"},
+		{"polybar", statusBar{label: "100% #1 1:05", color: "#ff0000"}, "%{F#ff0000}●%{F-} 100%% #1 1:05"},
+		{"tmux", bar, "#[fg=#ff0000]●#[default] R&D <zei> 1:05"},
+		{"tmux", statusBar{label: "100% #1 1:05"}, "100% ##1 1:05"},
+		{"tmux", statusBar{label: "100% #1 1:05", color: "#ff0000"}, "#[fg=#ff0000]●#[default] 100% ##1 1:05"},
+	}
+
+	for _, c := range cases {
+		got, err := formatStatusBar(c.format, c.bar)
+		if err != nil {
+			t.Errorf("%s: %v", c.format, err)
+			continue
+		}
+
+		// waybar reads JSON, which may escape the HTML characters.
+		if c.format == "waybar" {
+			var gotJSON, wantJSON map[string]string
+			if json.Unmarshal([]byte(got), &gotJSON) != nil || json.Unmarshal([]byte(c.want), &wantJSON) != nil || !reflect.DeepEqual(gotJSON, wantJSON) {
+				t.Errorf("%s: got %s, want %s", c.format, got, c.want)
+			}
+			continue
+		}
+
+		if got != c.want {
+			t.Errorf("%s: got %s, want %s", c.format, got, c.want)
+		}
+	}
+}
+
+func TestReadCachedStatus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zei")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "status.json")
+
+	// the file is removed once the device disconnected.
+	status, err := readCachedStatus(path, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bar, err := newStatusBar(time.UTC, status, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bar.class != "disconnected" {
+		t.Errorf("got status bar %+v without a status file, want disconnected", bar)
+	}
+
+	err = statusfile.Write(path, &zeid.CurrentActivityResp{IsIdle: true, DeviceConnected: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err = readCachedStatus(path, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bar, err = newStatusBar(time.UTC, status, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bar.class != "idle" {
+		t.Errorf("got status bar %+v, want idle", bar)
+	}
+}
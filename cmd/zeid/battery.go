@@ -0,0 +1,16 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/0xAX/notificator"
+)
+
+func notifyLowBattery(notify *notificator.Notificator, serial string, level int) error {
+	return notify.Push(
+		"Low battery",
+		fmt.Sprintf("The battery of ZEI device %s is at %d%%, charge it before tracking stops", serial, level),
+		"",
+		notificator.UR_CRITICAL,
+	)
+}
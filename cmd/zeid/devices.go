@@ -0,0 +1,458 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pauldub/zei/pkg/statusfile"
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pauldub/zei/pkg/zeidsvc"
+	"github.com/0xAX/notificator"
+	"github.com/go-ble/ble"
+	"github.com/mitchellh/cli"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// reconnectDelay is the time waited before reconnecting to a device which
+// disconnected.
+const reconnectDelay = 5 * time.Second
+
+// deviceConfig is the configuration of a device, devices without serial
+// number are chosen interactively.
+type deviceConfig struct {
+	serial    string
+	apiKey    string
+	apiSecret string
+	profiles  []zeidsvc.Profile
+}
+
+// devicesFile is the declaration of the devices to connect to, devices
+// without credentials or profiles use the ones of the command line.
+type devicesFile struct {
+	Devices []struct {
+		Serial    string `yaml:"serial"`
+		APIKey    string `yaml:"api_key"`
+		APISecret string `yaml:"api_secret"`
+		Profiles  string `yaml:"profiles"`
+	} `yaml:"devices"`
+}
+
+// deviceConfigs returns the configuration of the devices given on the
+// command line and in the devices file, a single device chosen
+// interactively when there are none.
+func deviceConfigs() ([]deviceConfig, error) {
+	profiles, err := readProfiles(*profilesPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read profiles")
+	}
+
+	var (
+		configs []deviceConfig
+		seen    = map[string]bool{}
+	)
+
+	for _, serial := range strings.Split(*zeiSerialNumber, ",") {
+		serial = strings.TrimSpace(serial)
+		if serial == "" || seen[serial] {
+			continue
+		}
+		seen[serial] = true
+
+		configs = append(configs, deviceConfig{serial, *zeiAPIKey, *zeiAPISecret, profiles})
+	}
+
+	if *devicesPath != "" {
+		b, err := ioutil.ReadFile(*devicesPath)
+		if err != nil {
+			return nil, err
+		}
+
+		var file devicesFile
+
+		err = yaml.UnmarshalStrict(b, &file)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse devices")
+		}
+
+		for _, d := range file.Devices {
+			if d.Serial == "" {
+				return nil, errors.New("devices must have a serial number")
+			}
+			if seen[d.Serial] {
+				return nil, errors.Errorf("device %q is declared twice", d.Serial)
+			}
+			seen[d.Serial] = true
+
+			config := deviceConfig{d.Serial, *zeiAPIKey, *zeiAPISecret, profiles}
+			if d.APIKey != "" || d.APISecret != "" {
+				config.apiKey, config.apiSecret = d.APIKey, d.APISecret
+			}
+			if d.Profiles != "" {
+				config.profiles, err = readProfiles(d.Profiles)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to read profiles of device %q", d.Serial)
+				}
+			}
+
+			configs = append(configs, config)
+		}
+	}
+
+	if len(configs) == 0 {
+		configs = append(configs, deviceConfig{"", *zeiAPIKey, *zeiAPISecret, profiles})
+	}
+
+	return configs, nil
+}
+
+// deviceRunner connects to the devices and runs a service for each of them.
+type deviceRunner struct {
+	devices *zeidsvc.Devices
+	states  *profileStates
+	notify  *notificator.Notificator
+	ui      cli.Ui
+	rules   zeidsvc.ReminderRules
+
+	scan scanner
+
+	// guards the serial numbers of the connected devices.
+	connMu    sync.Mutex
+	connected map[string]bool
+}
+
+// run connects to a device and runs its service, reconnecting when the
+// device disconnects or fails, until ctx is done.
+func (r *deviceRunner) run(ctx context.Context, config deviceConfig, primary bool) {
+	for reconnect := false; ; reconnect = true {
+		if reconnect {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(reconnectDelay):
+			}
+		}
+
+		conn, serial, err := r.connect(ctx, config.serial)
+		if _, ok := err.(askError); ok {
+			logger.Error("failed to choose the device, its serial number must be configured", "err", err)
+			return
+		}
+		if err != nil {
+			logger.Error("failed to connect to device", "device", config.serial, "reconnect_in", reconnectDelay, "err", err)
+			continue
+		}
+
+		// the device is reconnected without asking again.
+		config.serial = serial
+
+		if reconnect {
+			reconnectsTotal.Inc(serial)
+		}
+
+		logger.Info("device connected", "device", serial)
+
+		err = r.serve(ctx, config, conn, primary)
+
+		r.connMu.Lock()
+		delete(r.connected, serial)
+		r.connMu.Unlock()
+
+		if err != nil {
+			logger.Error("failed to run device", "device", serial, "reconnect_in", reconnectDelay, "err", err)
+		} else {
+			logger.Warn("device disconnected", "device", serial, "reconnect_in", reconnectDelay)
+		}
+	}
+}
+
+// askError is returned when the user could not be asked about a device.
+type askError struct {
+	error
+}
+
+// connect connects to the device with the given serial number, or to the
+// first device accepted by the user when serial is empty. The user is asked
+// about the advertised devices while the scan goes on, the accepted device
+// is connected once advertised again.
+func (r *deviceRunner) connect(ctx context.Context, serial string) (ble.Client, string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu sync.Mutex
+		// the user is asked once about each device while waiting.
+		asked    = map[string]bool{}
+		accepted = map[string]bool{}
+
+		candidates = make(chan string, 1)
+		result     = make(chan scanResult, 1)
+	)
+
+	go func() {
+		conn, found, err := r.scan.connect(ctx, func(serialNumber string) bool {
+			r.connMu.Lock()
+			connected := r.connected[serialNumber]
+			r.connMu.Unlock()
+
+			if connected {
+				return false
+			}
+			if serial != "" {
+				return serialNumber == serial
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if accepted[serialNumber] {
+				return true
+			}
+
+			if !asked[serialNumber] {
+				select {
+				case candidates <- serialNumber:
+					asked[serialNumber] = true
+				default:
+					// the user is asked about another device.
+				}
+			}
+
+			return false
+		})
+
+		result <- scanResult{conn, found, err}
+	}()
+
+	for {
+		select {
+		case res := <-result:
+			if res.err != nil {
+				return nil, "", res.err
+			}
+
+			r.connMu.Lock()
+			r.connected[res.serial] = true
+			r.connMu.Unlock()
+
+			return res.conn, res.serial, nil
+		case serialNumber := <-candidates:
+			answer, err := r.ui.Ask(fmt.Sprintf("Connect to ZEI device %q? (y/n)", serialNumber))
+			if err != nil {
+				cancel()
+				if res := <-result; res.err == nil {
+					res.conn.CancelConnection()
+				}
+
+				return nil, "", askError{errors.Wrap(err, "failed to ask the user")}
+			}
+
+			if strings.HasPrefix(answer, "y") {
+				mu.Lock()
+				accepted[serialNumber] = true
+				mu.Unlock()
+			}
+		}
+	}
+}
+
+// serve runs the service of a connected device until it disconnects, the
+// status of the primary device is also written to the default status file.
+func (r *deviceRunner) serve(ctx context.Context, config deviceConfig, conn ble.Client, primary bool) error {
+	defer conn.CancelConnection()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	profile, err := conn.DiscoverProfile(true)
+	if err != nil {
+		return errors.Wrap(err, "failed to discover device profile")
+	}
+
+	orientation, ok := profile.Find(ble.NewCharacteristic(orientationCharacteristic)).(*ble.Characteristic)
+	if !ok {
+		return errors.New("failed to find orientation characteristic")
+	}
+
+	svc, err := zeidsvc.NewService(
+		ctx, config.serial, config.apiKey, config.apiSecret, conn, profile, clk, apiObserver(config.serial),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize zeid service")
+	}
+
+	svc.SetProfiles(config.profiles, func(name string) {
+		err := r.states.set(config.serial, name)
+		if err != nil {
+			logger.Error("failed to persist active profile", "device", config.serial, "profile", name, "err", err)
+		}
+	})
+
+	activeProfile, err := r.states.get(config.serial)
+	if err != nil {
+		logger.Error("failed to read active profile", "device", config.serial, "err", err)
+	} else if activeProfile != zeidsvc.DefaultProfile {
+		err = svc.UseProfile(ctx, activeProfile)
+		if err != nil {
+			logger.Error("failed to use profile", "device", config.serial, "profile", activeProfile, "err", err)
+		}
+	}
+
+	notify := r.notify
+
+	focus := zeidsvc.NewFocus(ctx, svc, zeidsvc.FocusOptions{
+		Side:      *focusSide,
+		Work:      *focusWork,
+		Break:     *focusBreak,
+		Cycles:    *focusCycles,
+		BreakSide: *focusBreakSide,
+		Clock:     clk,
+		OnEvent: func(e zeidsvc.FocusEvent) {
+			err := notifyFocus(notify, e)
+			if err != nil {
+				logger.Warn("failed to send notification", "device", config.serial, "err", err)
+			}
+		},
+		OnError: func(err error) {
+			logger.Error("failed to track focus break", "device", config.serial, "err", err)
+		},
+	})
+	defer focus.Stop()
+
+	flipper := zeidsvc.NewFlipper(ctx, svc, zeidsvc.FlipOptions{
+		SettleDelay: *settleDelay,
+		MinDuration: *minDuration,
+		Clock:       clk,
+		OnChange: func(from, to zei.Activity) {
+			logger.Info(
+				"activity changed", "device", config.serial, "side", to.DeviceSide,
+				"activity_id", to.ID, "activity", to.Name, "previous_activity_id", from.ID,
+			)
+
+			var err error
+			if to.Name == "Idle" {
+				err = notifyStop(notify, from)
+			} else {
+				err = notifyStart(notify, to)
+			}
+			if err != nil {
+				logger.Warn("failed to send notification", "device", config.serial, "err", err)
+			}
+
+			if *focusSide != 0 {
+				focus.Changed(from, to)
+			}
+		},
+		OnError: func(err error) {
+			logger.Error("failed to track activity change", "device", config.serial, "err", err)
+		},
+	})
+	defer flipper.Stop()
+
+	svc.SetQueueDepth(flipper.QueueDepth)
+
+	svc.SetReminderRules(r.rules)
+
+	reminders := zeidsvc.NewReminders(ctx, svc, zeidsvc.ReminderOptions{
+		Clock: clk,
+		OnReminder: func(reminder zeidsvc.Reminder) {
+			err := notifyReminder(notify, reminder)
+			if err != nil {
+				logger.Warn("failed to send notification", "device", config.serial, "err", err)
+			}
+		},
+		OnError: func(err error) {
+			logger.Error("failed to stop tracking at the end of the day", "device", config.serial, "err", err)
+		},
+	})
+	reminders.Start()
+	defer reminders.Stop()
+
+	if *idleTimeout > 0 {
+		stopper := zeidsvc.NewIdleStopper(ctx, svc, zeidsvc.IdleOptions{
+			Timeout: *idleTimeout,
+			Clock:   clk,
+			OnStop: func(a zei.Activity, since time.Time) {
+				err := notifyIdleStop(notify, a, since)
+				if err != nil {
+					logger.Warn("failed to send notification", "device", config.serial, "err", err)
+				}
+			},
+			OnResume: func(gap zeidsvc.IdleGap) {
+				err := notifyIdleResume(notify, gap)
+				if err != nil {
+					logger.Warn("failed to send notification", "device", config.serial, "err", err)
+				}
+			},
+			OnError: func(err error) {
+				logger.Error("failed to track idle change", "device", config.serial, "err", err)
+			},
+		})
+		defer stopper.Stop()
+
+		go watchIdle(ctx, config.serial, stopper)
+	}
+
+	if *batteryLow > 0 {
+		if _, err := svc.BatteryLevel(); err != nil {
+			logger.Warn("battery level is unavailable", "device", config.serial, "err", err)
+		} else {
+			battery := zeidsvc.NewBatteryMonitor(svc, zeidsvc.BatteryOptions{
+				Threshold: *batteryLow,
+				Interval:  *batteryInterval,
+				Clock:     clk,
+				OnLow: func(level int) {
+					err := notifyLowBattery(notify, config.serial, level)
+					if err != nil {
+						logger.Warn("failed to send notification", "device", config.serial, "err", err)
+					}
+				},
+				OnError: func(err error) {
+					logger.Warn("failed to read battery level", "device", config.serial, "err", err)
+				},
+			})
+			battery.Start()
+			defer battery.Stop()
+		}
+	}
+
+	err = conn.Subscribe(orientation, true, func(val []byte) {
+		side := int(val[0])
+		if side < 1 || side > 8 {
+			side = 0
+		}
+
+		logger.Debug("device changed side", "device", config.serial, "side", side)
+
+		svc.RecordOrientation(clk.Now())
+		flipsTotal.Inc(config.serial)
+
+		flipper.Flip(side)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to subscribe to orientation changes")
+	}
+
+	if *statusFile != "" {
+		paths := []string{statusfile.DevicePath(*statusFile, config.serial)}
+		if primary {
+			paths = append(paths, *statusFile)
+		}
+
+		go writeStatus(ctx, svc, paths, *statusInterval)
+	}
+
+	r.devices.Add(svc)
+	defer r.devices.Remove(svc)
+
+	select {
+	case <-ctx.Done():
+	case <-conn.Disconnected():
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-ble/ble"
+	"github.com/mitchellh/cli"
+)
+
+func TestDeviceRunnerConnectAsks(t *testing.T) {
+	ble.SetDefaultDevice(&scanDevice{t: t, serials: []string{"a", "b"}})
+	defer ble.SetDefaultDevice(nil)
+
+	ui := &cli.MockUi{InputReader: strings.NewReader("n\ny\n")}
+	r := &deviceRunner{ui: ui, connected: map[string]bool{}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	conn, serial, err := r.connect(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if serial != "b" || conn.Addr().String() != "b" {
+		t.Errorf("connected to %s (%s), want b", serial, conn.Addr())
+	}
+	if !r.connected["b"] {
+		t.Error("the device is not recorded as connected")
+	}
+
+	asked := ui.OutputWriter.String()
+	if !strings.Contains(asked, `"a"`) || !strings.Contains(asked, `"b"`) {
+		t.Errorf("asked %q, want both devices", asked)
+	}
+}
+
+func TestDeviceRunnerConnectAskFails(t *testing.T) {
+	ble.SetDefaultDevice(&scanDevice{t: t, serials: []string{"a"}})
+	defer ble.SetDefaultDevice(nil)
+
+	// the input is closed.
+	r := &deviceRunner{ui: &cli.MockUi{InputReader: strings.NewReader("")}, connected: map[string]bool{}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, _, err := r.connect(ctx, "")
+	if _, ok := err.(askError); !ok {
+		t.Errorf("got error %v, want an askError", err)
+	}
+}
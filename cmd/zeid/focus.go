@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pauldub/zei/pkg/zeidsvc"
+	"github.com/0xAX/notificator"
+)
+
+func notifyFocus(notify *notificator.Notificator, e zeidsvc.FocusEvent) error {
+	cycle := fmt.Sprintf("cycle %d", e.Session.Cycle)
+	if e.Session.Cycles > 0 {
+		cycle = fmt.Sprintf("cycle %d/%d", e.Session.Cycle, e.Session.Cycles)
+	}
+
+	switch e.Kind {
+	case zeidsvc.FocusStarted:
+		return notify.Push(
+			"Focus",
+			fmt.Sprintf("Focus on %s until %s (%s)", e.Session.Activity.Name, e.Session.EndsAt.Local().Format("15:04"), cycle),
+			"",
+			notificator.UR_NORMAL,
+		)
+	case zeidsvc.FocusBreakStarted:
+		return notify.Push(
+			"Break",
+			fmt.Sprintf("Take a break until %s (%s)", e.Session.EndsAt.Local().Format("15:04"), cycle),
+			"",
+			notificator.UR_CRITICAL,
+		)
+	case zeidsvc.FocusEnded:
+		return notify.Push(
+			"Focus session ended",
+			fmt.Sprintf("Focus on %s ended (%s)", e.Session.Activity.Name, cycle),
+			"",
+			notificator.UR_NORMAL,
+		)
+	}
+
+	return nil
+}
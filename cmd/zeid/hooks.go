@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+// requestIDHeader carries the ID of a request, it is generated when the
+// client does not send one.
+const requestIDHeader = "X-Request-Id"
+
+type requestIDKey struct{}
+
+// withRequestID attaches an ID to each request, it is returned to the
+// client and logged with the entries of the request.
+func withRequestID(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if id == "" {
+			b := make([]byte, 8)
+			rand.Read(b)
+			id = hex.EncodeToString(b)
+		}
+
+		w.Header().Set(requestIDHeader, id)
+
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+func requestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+type requestStartKey struct{}
+
+// startHooks returns twirp server hooks recording the start of the
+// requests, for the logging and metrics hooks chained after them.
+func startHooks() *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		RequestReceived: func(ctx context.Context) (context.Context, error) {
+			return context.WithValue(ctx, requestStartKey{}, clk.Now()), nil
+		},
+	}
+}
+
+// requestDuration returns the time elapsed since the start of the request.
+func requestDuration(ctx context.Context) (time.Duration, bool) {
+	start, ok := ctx.Value(requestStartKey{}).(time.Time)
+	if !ok {
+		return 0, false
+	}
+
+	return clk.Since(start), true
+}
+
+type requestErrorKey struct{}
+
+// loggingHooks returns twirp server hooks logging each request once it was
+// handled, at Info when it completed and at Warn when it failed, or Error
+// for internal errors.
+func loggingHooks() *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		Error: func(ctx context.Context, err twirp.Error) context.Context {
+			return context.WithValue(ctx, requestErrorKey{}, err)
+		},
+		ResponseSent: func(ctx context.Context) {
+			method, _ := twirp.MethodName(ctx)
+			code, _ := twirp.StatusCode(ctx)
+
+			args := []interface{}{"request_id", requestID(ctx), "method", method, "code", code}
+			if duration, ok := requestDuration(ctx); ok {
+				args = append(args, "duration", duration)
+			}
+
+			err, failed := ctx.Value(requestErrorKey{}).(twirp.Error)
+			if !failed {
+				logger.Info("request handled", args...)
+				return
+			}
+
+			args = append(args, "error_code", err.Code(), "err", err.Msg())
+			if err.Code() == twirp.Internal {
+				logger.Error("request failed", args...)
+			} else {
+				logger.Warn("request failed", args...)
+			}
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pauldub/zei/pkg/idle"
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pauldub/zei/pkg/zeidsvc"
+	"github.com/0xAX/notificator"
+	"github.com/godbus/dbus"
+)
+
+// watchIdle feeds the session idleness changes to the stopper of a device
+// until ctx is done.
+func watchIdle(ctx context.Context, serial string, stopper *zeidsvc.IdleStopper) {
+	var (
+		events = make(chan idle.Event)
+		watch  func(conn *dbus.Conn) error
+		conn   *dbus.Conn
+		err    error
+	)
+
+	switch *idleSource {
+	case "logind":
+		conn, err = dbus.SystemBus()
+		watch = func(conn *dbus.Conn) error {
+			return idle.WatchLogind(ctx, conn, clk, *idleSession, events)
+		}
+	case "screensaver":
+		conn, err = dbus.SessionBus()
+		watch = func(conn *dbus.Conn) error {
+			return idle.WatchScreenSaver(ctx, conn, clk, events)
+		}
+	default:
+		logger.Error("unknown idle source", "device", serial, "source", *idleSource)
+		return
+	}
+	if err != nil {
+		logger.Error("failed to connect to D-Bus", "device", serial, "err", err)
+		return
+	}
+
+	go func() {
+		err := watch(conn)
+		if err != nil && err != context.Canceled {
+			logger.Error("failed to watch session idleness", "device", serial, "err", err)
+		}
+		close(events)
+	}()
+
+	for event := range events {
+		logger.Info("session idleness changed", "device", serial, "idle", event.Idle, "since", event.Since)
+
+		stopper.Idle(event.Idle, event.Since)
+	}
+}
+
+func notifyIdleStop(notify *notificator.Notificator, a zei.Activity, since time.Time) error {
+	return notify.Push(
+		"Stopping activity",
+		fmt.Sprintf("%s was stopped, idle since %s", a.Name, since.Local().Format("15:04")),
+		"",
+		notificator.UR_NORMAL,
+	)
+}
+
+func notifyIdleResume(notify *notificator.Notificator, gap zeidsvc.IdleGap) error {
+	return notify.Push(
+		"Resuming activity",
+		fmt.Sprintf(
+			"%s resumed after %s idle. Run 'zei idle keep' to track the idle time or 'zei idle discard' to drop it.",
+			gap.Activity.Name,
+			gap.To.Sub(gap.From).Truncate(time.Minute),
+		),
+		"",
+		notificator.UR_NORMAL,
+	)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/pauldub/zei/pkg/zeidapi"
+	"github.com/pkg/errors"
+)
+
+// serveAPI serves handler on the API address and socket, until one of them
+// fails. Requests to the API address must carry the API token, unless it
+// is disabled, and are served over TLS when a certificate is configured.
+// The socket is protected by its permissions.
+func serveAPI(handler http.Handler) error {
+	var listeners []func() error
+
+	if *apiAddress != "" {
+		tcpHandler := handler
+
+		if *apiTokenFile != "" {
+			token, err := zeidapi.EnsureToken(*apiTokenFile)
+			if err != nil {
+				return err
+			}
+
+			tcpHandler = zeidapi.RequireToken(token, handler)
+		} else if *tlsClientCA == "" && !isLoopback(*apiAddress) {
+			logger.Warn("API is served without authentication", "addr", *apiAddress)
+		}
+
+		server := &http.Server{Addr: *apiAddress, Handler: tcpHandler}
+
+		if *tlsCert != "" || *tlsKey != "" {
+			config, err := zeidapi.ServerTLSConfig(*tlsCert, *tlsKey, *tlsClientCA)
+			if err != nil {
+				return err
+			}
+			server.TLSConfig = config
+		} else if *tlsClientCA != "" {
+			return errors.New("client certificates require a TLS certificate and key")
+		} else if !isLoopback(*apiAddress) {
+			logger.Warn("API is served without TLS", "addr", *apiAddress)
+		}
+
+		listeners = append(listeners, func() error {
+			logger.Info("serving API", "addr", *apiAddress, "tls", server.TLSConfig != nil)
+
+			if server.TLSConfig != nil {
+				// the certificate is part of the TLS configuration.
+				return server.ListenAndServeTLS("", "")
+			}
+			return server.ListenAndServe()
+		})
+	}
+
+	if *apiSocket != "" {
+		l, err := listenUnix(*apiSocket)
+		if err != nil {
+			return err
+		}
+
+		listeners = append(listeners, func() error {
+			logger.Info("serving API", "socket", *apiSocket)
+
+			return http.Serve(l, handler)
+		})
+	}
+
+	if len(listeners) == 0 {
+		return errors.New("an API address or socket is required")
+	}
+
+	errs := make(chan error, len(listeners))
+	for _, listen := range listeners {
+		go func(listen func() error) {
+			errs <- listen()
+		}(listen)
+	}
+
+	return <-errs
+}
+
+// listenUnix listens on the Unix socket at path, only the user can connect
+// to it.
+func listenUnix(path string) (net.Listener, error) {
+	dir := filepath.Dir(path)
+
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create API socket directory")
+	}
+
+	err = zeidapi.CheckSocketDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// the socket of a previous run is left behind when zeid is killed.
+	if fi, err := os.Lstat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		os.Remove(path)
+	}
+
+	// the socket is created without permissions for the group and others,
+	// the umask is shared by the process but only makes the files created
+	// meanwhile more restrictive.
+	mask := syscall.Umask(0177)
+	l, err := net.Listen("unix", path)
+	syscall.Umask(mask)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to listen on API socket")
+	}
+
+	return l, nil
+}
+
+// isLoopback returns whether addr only listens on the loopback interface.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
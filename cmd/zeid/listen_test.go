@@ -0,0 +1,49 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListenUnix(t *testing.T) {
+	root, err := ioutil.TempDir("", "zeid-listen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	path := filepath.Join(root, "zei", "zeid.sock")
+
+	l, err := listenUnix(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0600 {
+		t.Errorf("got socket mode %s, want -rw-------", perm)
+	}
+
+	// the socket is not served in a directory others can write to.
+	shared := filepath.Join(root, "shared")
+
+	err = os.Mkdir(shared, 0777)
+	if err == nil {
+		err = os.Chmod(shared, 0777)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err = listenUnix(filepath.Join(shared, "zeid.sock"))
+	if err == nil {
+		l.Close()
+		t.Error("listened in a shared directory")
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	logxi "github.com/mgutz/logxi/v1"
+	"github.com/pkg/errors"
+)
+
+// Log formats of the -log-format flag.
+const (
+	logFormatAuto     = "auto"
+	logFormatLogfmt   = "logfmt"
+	logFormatJournald = "journald"
+	logFormatJSON     = "json"
+	logFormatHappy    = "happy"
+)
+
+// logger is the logger of zeid, it discards entries until setupLogging is
+// called.
+var logger logxi.Logger = logxi.NullLog
+
+var logLevels = map[string]int{
+	"debug": logxi.LevelDebug,
+	"info":  logxi.LevelInfo,
+	"warn":  logxi.LevelWarn,
+	"error": logxi.LevelError,
+}
+
+// setupLogging configures logger with the given level and format, the auto
+// format writes to journald when zeid is run by systemd, colored entries on
+// a terminal and logfmt otherwise.
+func setupLogging(level, format string) error {
+	lvl, ok := logLevels[level]
+	if !ok {
+		return errors.Errorf("unknown log level %q, expected debug, info, warn or error", level)
+	}
+
+	if format == logFormatAuto {
+		switch {
+		case os.Getenv("JOURNAL_STREAM") != "":
+			format = logFormatJournald
+		case isatty.IsTerminal(os.Stderr.Fd()):
+			format = logFormatHappy
+		default:
+			format = logFormatLogfmt
+		}
+	}
+
+	var formatter logxi.Formatter
+
+	switch format {
+	case logFormatLogfmt:
+		formatter = &logfmtFormatter{time: true}
+	case logFormatJournald:
+		// journald timestamps the entries and reads their priority from
+		// the prefix.
+		formatter = &logfmtFormatter{priority: true}
+	case logFormatJSON:
+		formatter = jsonFormatter{}
+	case logFormatHappy:
+		formatter = logxi.NewHappyDevFormatter("zeid")
+	default:
+		return errors.Errorf("unknown log format %q, expected auto, logfmt, journald, json or happy", format)
+	}
+
+	l := logxi.NewLogger3(logxi.NewConcurrentWriter(os.Stderr), "zeid", formatter)
+	l.SetLevel(lvl)
+	logger = l
+
+	return nil
+}
+
+// fatal logs an error entry and exits.
+func fatal(msg string, args ...interface{}) {
+	logger.Error(msg, args...)
+	os.Exit(1)
+}
+
+var logfmtLevels = map[int]string{
+	logxi.LevelFatal: "fatal",
+	logxi.LevelError: "error",
+	logxi.LevelWarn:  "warn",
+	logxi.LevelInfo:  "info",
+	logxi.LevelDebug: "debug",
+	logxi.LevelTrace: "trace",
+}
+
+// logfmtFormatter writes entries as single lines of key=value pairs.
+type logfmtFormatter struct {
+	// time prefixes the entries with the time.
+	time bool
+
+	// priority prefixes the entries with their syslog priority, as read by
+	// journald.
+	priority bool
+}
+
+func (f *logfmtFormatter) Format(w io.Writer, level int, msg string, args []interface{}) {
+	var pairs []string
+
+	if f.time {
+		pairs = append(pairs, logfmtPair("time", clk.Now().Format(time.RFC3339)))
+	}
+	pairs = append(pairs, logfmtPair("level", logfmtLevels[level]), logfmtPair("msg", msg))
+
+	for _, field := range logFields(args) {
+		pairs = append(pairs, logfmtPair(field.key, field.value))
+	}
+
+	line := strings.Join(pairs, " ")
+	if f.priority {
+		line = fmt.Sprintf("<%d>%s", syslogPriority(level), line)
+	}
+
+	io.WriteString(w, line+"\n")
+}
+
+// syslogPriority returns the syslog priority, from 0 to 7, of a level of
+// logxi. Most levels of logxi are syslog priorities, but not all of them.
+func syslogPriority(level int) int {
+	switch {
+	case level <= logxi.LevelEmergency:
+		return 0
+	case level >= logxi.LevelDebug:
+		return 7
+	}
+
+	return level
+}
+
+func logfmtPair(key string, value interface{}) string {
+	s := fmt.Sprint(value)
+	if s == "" || strings.ContainsAny(s, " =\"\n\t") {
+		s = strconv.Quote(s)
+	}
+
+	return fmt.Sprintf("%s=%s", key, s)
+}
+
+// jsonFormatter writes entries as single line JSON objects.
+type jsonFormatter struct{}
+
+func (jsonFormatter) Format(w io.Writer, level int, msg string, args []interface{}) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, `{"time":%q,"level":%q,"msg":%s`, clk.Now().Format(time.RFC3339), logfmtLevels[level], jsonValue(msg))
+	for _, field := range logFields(args) {
+		fmt.Fprintf(&buf, ",%s:%s", jsonValue(field.key), jsonValue(field.value))
+	}
+	buf.WriteString("}\n")
+
+	w.Write(buf.Bytes())
+}
+
+func jsonValue(value interface{}) []byte {
+	b, err := json.Marshal(value)
+	if err != nil {
+		b, _ = json.Marshal(fmt.Sprint(value))
+	}
+
+	return b
+}
+
+type logField struct {
+	key   string
+	value interface{}
+}
+
+// logFields returns the fields of the key value pairs of args, errors and
+// stringers are rendered as strings.
+func logFields(args []interface{}) []logField {
+	var fields []logField
+
+	for i := 0; i < len(args); i += 2 {
+		key, ok := args[i].(string)
+		if !ok || i+1 == len(args) {
+			fields = append(fields, logField{"BAD_KEY", fmt.Sprint(args[i:]...)})
+			break
+		}
+
+		value := args[i+1]
+		switch v := value.(type) {
+		case error:
+			value = v.Error()
+		case fmt.Stringer:
+			value = v.String()
+		}
+
+		fields = append(fields, logField{key, value})
+	}
+
+	return fields
+}
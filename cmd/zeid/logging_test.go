@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	logxi "github.com/mgutz/logxi/v1"
+)
+
+func TestJournaldPriority(t *testing.T) {
+	cases := []struct {
+		level    int
+		priority string
+	}{
+		{logxi.LevelEmergency, "<0>"},
+		{logxi.LevelAlert, "<1>"},
+		{logxi.LevelFatal, "<2>"},
+		{logxi.LevelError, "<3>"},
+		{logxi.LevelWarn, "<4>"},
+		{logxi.LevelNotice, "<5>"},
+		{logxi.LevelInfo, "<6>"},
+		{logxi.LevelDebug, "<7>"},
+		{logxi.LevelTrace, "<7>"},
+	}
+
+	f := &logfmtFormatter{priority: true}
+
+	for _, c := range cases {
+		var b bytes.Buffer
+		f.Format(&b, c.level, "device connected", nil)
+
+		if !strings.HasPrefix(b.String(), c.priority) {
+			t.Errorf("level %d: got %q, want the priority %s", c.level, b.String(), c.priority)
+		}
+	}
+}
@@ -4,43 +4,75 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"log"
 	"net/http"
 	"os"
-	"strings"
 	"sync"
+	"time"
 
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/metrics"
+	"github.com/pauldub/zei/pkg/statusfile"
 	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pauldub/zei/pkg/zeidapi"
 	"github.com/pauldub/zei/pkg/zeidsvc"
 	"github.com/pauldub/zei/rpc/zeid"
 	"github.com/0xAX/notificator"
 	"github.com/go-ble/ble"
 	"github.com/mitchellh/cli"
+	"github.com/twitchtv/twirp"
 )
 
 var (
 	orientationService        = "c7e70010c84711e681758c89a55d403c"
 	orientationCharacteristic = ble.MustParse("c7e70012c84711e681758c89a55d403c")
 
-	zeiSerialNumber = flag.String("serial-number", "", "ZEI device serial number (optional)")
-	zeiAPIKey       = flag.String("api-key", "", "ZEI api key")
-	zeiAPISecret    = flag.String("api-secret", "", "ZEI api secret")
+	zeiSerialNumber = flag.String("serial-number", "", "Comma separated serial numbers of the ZEI devices to connect to (optional, default: $ZEI_SERIAL_NUMBER)")
+	zeiAPIKey       = flag.String("api-key", "", "ZEI api key (default: $ZEI_API_KEY)")
+	zeiAPISecret    = flag.String("api-secret", "", "ZEI api secret (default: $ZEI_API_SECRET)")
 	showSide        = flag.Bool("show-side", false, "Show activity side in notifications (default: false)")
-	apiAddress      = flag.String("api-addr", ":8594", "Address for API to listen on (default: ':8594')")
+	apiAddress      = flag.String("api-addr", "127.0.0.1:8594", "Address for API to listen on, empty to disable (default: '127.0.0.1:8594')")
+	apiSocket       = flag.String("api-socket", zeidapi.DefaultSocketPath(), "Unix socket the API is also served on, only the user can connect to it, empty to disable")
+	tlsCert         = flag.String("tls-cert", "", "PEM certificate served over TLS on the API address (optional)")
+	tlsKey          = flag.String("tls-key", "", "PEM key of the TLS certificate (optional)")
+	tlsClientCA     = flag.String("tls-client-ca", "", "PEM CA which must have signed the certificates of the clients of the API address (optional)")
+	apiTokenFile    = flag.String("api-token-file", zeidapi.DefaultTokenPath(), "File holding the token required by the API address, generated when missing, empty to disable")
+	settleDelay     = flag.Duration("settle-delay", time.Second, "Time the device must rest on a side before switching activity (default: 1s)")
+	minDuration     = flag.Duration("min-duration", 0, "Minimum duration of an entry, shorter entries are merged into the surrounding activity (default: 0, disabled)")
+	remindAfter     = flag.Duration("remind-after", 0, "Notify after an activity has been tracked continuously for this long (default: 0, disabled)")
+	endOfDay        = flag.String("end-of-day", "", "Time of day at which tracking is stopped, e.g. '19:00' (optional)")
+	workHours       = flag.String("work-hours", "", "Working hours of week days, e.g. '09:00-18:00' (optional)")
+	untrackedAfter  = flag.Duration("untracked-after", 0, "Warn when nothing has been tracked for this long during working hours (default: 0, disabled)")
+	focusSide       = flag.Int("focus-side", 0, "Side of the device starting a focus session (default: 0, disabled)")
+	focusWork       = flag.Duration("focus-work", 25*time.Minute, "Duration of the work phase of a focus cycle (default: 25m)")
+	focusBreak      = flag.Duration("focus-break", 5*time.Minute, "Duration of the break phase of a focus cycle (default: 5m)")
+	focusCycles     = flag.Int("focus-cycles", 4, "Number of cycles of a focus session, 0 to repeat until the device is flipped (default: 4)")
+	focusBreakSide  = flag.Int("focus-break-side", 0, "Side of the activity tracked during focus breaks (default: 0, keep tracking the focus activity)")
+	statusFile      = flag.String("status-file", statusfile.DefaultPath(), "File the current activity is written to for status bars, empty to disable")
+	statusInterval  = flag.Duration("status-interval", 5*time.Second, "Interval between two writes of the status file (default: 5s)")
+	idleTimeout     = flag.Duration("idle-timeout", 0, "Stop tracking once the session has been idle for this long (default: 0, disabled)")
+	idleSource      = flag.String("idle-source", "logind", "Source of the session idleness, 'logind' or 'screensaver' (default: 'logind')")
+	idleSession     = flag.String("idle-session", "auto", "logind session monitored for idleness (default: 'auto')")
+	profilesPath    = flag.String("profiles", "", "YAML file declaring the profiles of the device (optional)")
+	profileState    = flag.String("profile-state", defaultProfileState(), "File the active profile of each device is persisted to, empty to disable")
+	batteryLow      = flag.Int("battery-low", 20, "Battery level, in percents, at which a low battery notification is sent (default: 20, 0 to disable)")
+	batteryInterval = flag.Duration("battery-interval", 15*time.Minute, "Interval between two reads of the battery level (default: 15m)")
+	devicesPath     = flag.String("devices", "", "YAML file declaring the devices to connect to, with their own account and profiles (optional)")
+	exposeMetrics   = flag.Bool("metrics", false, "Expose Prometheus metrics on /metrics of the API address, scrapes send the API token as a bearer token (bearer_token_file) unless -api-token-file is empty (default: false)")
+	logLevel        = flag.String("log-level", "info", "Minimum level of the logged entries, 'debug', 'info', 'warn' or 'error' (default: 'info')")
+	logFormat       = flag.String("log-format", logFormatAuto, "Format of the logs, 'auto', 'logfmt', 'journald', 'json' or 'happy' (default: 'auto', journald under systemd)")
+
+	clk = clock.Real
 )
 
 func main() {
 	var (
 		ctx = context.Background()
-		ui  = cli.BasicUi{
+		ui  = &cli.BasicUi{
 			Reader:      os.Stdin,
 			Writer:      os.Stdout,
 			ErrorWriter: os.Stderr,
 		}
 
-		connMutex   sync.Mutex
-		isConnected bool
-
 		notify = notificator.New(notificator.Options{
 			AppName: "ZEI",
 		})
@@ -48,133 +80,82 @@ func main() {
 
 	flag.Parse()
 
-	dev, err := getDevice()
-	if err != nil {
-		log.Fatal(err)
+	err := setFlagsFromEnv()
+	if err == nil {
+		err = setupLogging(*logLevel, *logFormat)
 	}
-
-	ble.SetDefaultDevice(dev)
-
-	conn, err := ble.Connect(ctx, func(a ble.Advertisement) bool {
-		connMutex.Lock()
-		defer connMutex.Unlock()
-
-		isZei := strings.ToUpper(a.LocalName()) == strings.ToUpper("Timeular ZEI")
-		if !isZei || isConnected {
-			return false
-		}
-
-		serialNumber := string(a.ManufacturerData())
-		if *zeiSerialNumber != "" {
-			return serialNumber == *zeiSerialNumber
-		}
-
-		answer, err := ui.Ask(fmt.Sprintf("Connect to ZEI device %q? (y/n)", serialNumber))
-		if err != nil {
-			ui.Error(err.Error())
-			os.Exit(1)
-			return false
-		}
-
-		if strings.HasPrefix(answer, "y") {
-			isConnected = true
-			return true
-		}
-
-		return false
-	})
 	if err != nil {
-		log.Fatal(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
-	defer conn.CancelConnection()
 
-	ui.Info("connection to device successful")
-
-	profile, err := conn.DiscoverProfile(true)
+	rules, err := reminderRules()
 	if err != nil {
-		log.Fatal(err)
+		fatal("invalid reminder rules", "err", err)
 	}
 
-	orientation, ok := profile.Find(ble.NewCharacteristic(orientationCharacteristic)).(*ble.Characteristic)
-	if !ok {
-		log.Fatalf("failed to initialize get orientation characteristic: %+v", err)
+	configs, err := deviceConfigs()
+	if err != nil {
+		fatal("invalid devices", "err", err)
 	}
 
-	svc, err := zeidsvc.NewService(
-		ctx, *zeiAPIKey, *zeiAPISecret, conn, profile,
-	)
+	dev, err := getDevice()
 	if err != nil {
-		log.Fatalf("failed to initialize zeid serrvice: %+v", err)
+		fatal("failed to open Bluetooth device", "err", err)
 	}
 
-	err = conn.Subscribe(orientation, true, func(val []byte) {
-		side := int(val[0])
-		if side < 1 || side > 8 {
-			side = 0
-		}
+	ble.SetDefaultDevice(dev)
 
-		log.Printf("changed side: %+v", side)
+	runner := &deviceRunner{
+		devices:   zeidsvc.NewDevices(clk),
+		states:    &profileStates{path: *profileState},
+		notify:    notify,
+		ui:        ui,
+		rules:     rules,
+		connected: map[string]bool{},
+	}
 
-		newActivity, ok := svc.GetActivity(int(val[0]))
-		if !ok {
-			return
-		}
+	hooks := twirp.ChainHooks(startHooks(), loggingHooks())
+	if *exposeMetrics {
+		hooks = twirp.ChainHooks(hooks, metricsHooks())
+	}
 
-		if newActivity.ID == svc.Current().ID {
-			return
-		}
+	handler := zeid.NewZeiServer(runner.devices, hooks)
 
-		notificationText := newActivity.Name
-		if *showSide {
-			notificationText = fmt.Sprintf("%s (%d)", notificationText, newActivity.DeviceSide)
-		}
+	mux := http.NewServeMux()
+	mux.Handle(zeid.ZeiPathPrefix, withRequestID(handler))
+
+	if *exposeMetrics {
+		registry := metrics.NewRegistry()
 
-		if newActivity.Name == "Idle" {
-			err = svc.Stop(ctx)
-			if err != nil {
-				log.Printf("failed to stop tracking of current activity: %+v", err)
-			}
-
-			err = notifyStop(notify, svc.Current())
-			if err != nil {
-				log.Printf("failed to send notification: %+v", err)
-			}
-		} else {
-			if !svc.IsIdle() {
-				err = svc.Stop(ctx)
-				if err != nil {
-					log.Printf("failed to stop tracking of current activity: %+v", err)
-				}
-			}
-
-			err = svc.Start(ctx, newActivity)
-			if err != nil {
-				log.Printf("failed to start tracking of new activity: %+v", err)
-			}
-
-			err = notifyStart(notify, newActivity)
-			if err != nil {
-				log.Printf("failed to send notification: %+v", err)
-			}
+		err = registerMetrics(registry, runner.devices)
+		if err != nil {
+			fatal("failed to register metrics", "err", err)
 		}
 
-		svc.SetActivity(newActivity)
-	})
-	if err != nil {
-		log.Fatal(err)
+		// /metrics is behind the API token like the API, a scrape
+		// without it gets 401.
+		mux.Handle("/metrics", registry.Handler())
 	}
 
-	handler := zeid.NewZeiServer(svc, nil)
-
-	mux := http.NewServeMux()
-	mux.Handle(zeid.ZeiPathPrefix, handler)
-
 	go func() {
-		log.Fatal(http.ListenAndServe(*apiAddress, mux))
+		err := serveAPI(mux)
+		fatal("failed to serve API", "err", err)
 	}()
 
-	<-conn.Disconnected()
+	var wg sync.WaitGroup
+
+	for i, config := range configs {
+		wg.Add(1)
+		go func(config deviceConfig, primary bool) {
+			defer wg.Done()
+			runner.run(ctx, config, primary)
+		}(config, i == 0)
+	}
+
+	wg.Wait()
 }
+
 func notifyStart(notify *notificator.Notificator, a zei.Activity) error {
 	title := a.Name
 	if *showSide {
@@ -187,3 +168,34 @@ func notifyStart(notify *notificator.Notificator, a zei.Activity) error {
 func notifyStop(notify *notificator.Notificator, a zei.Activity) error {
 	return notify.Push("Stopping activity", a.Name, "", notificator.UR_NORMAL)
 }
+
+// flagEnvars are the environment variables of the flags which are not set on
+// the command line, so the credentials do not have to appear in it.
+var flagEnvars = map[string]string{
+	"serial-number": "ZEI_SERIAL_NUMBER",
+	"api-key":       "ZEI_API_KEY",
+	"api-secret":    "ZEI_API_SECRET",
+}
+
+// setFlagsFromEnv sets the flags of flagEnvars missing from the command line
+// from their environment variable.
+func setFlagsFromEnv() error {
+	set := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	for name, envar := range flagEnvars {
+		value, ok := os.LookupEnv(envar)
+		if !ok || set[name] {
+			continue
+		}
+
+		err := flag.Set(name, value)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %s", envar, err)
+		}
+	}
+
+	return nil
+}
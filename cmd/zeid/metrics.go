@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/pauldub/zei/pkg/metrics"
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pauldub/zei/pkg/zeidsvc"
+	"github.com/twitchtv/twirp"
+)
+
+// metrics are recorded even when they are not exposed.
+var (
+	flipsTotal = metrics.NewCounter(
+		"zeid_flips_total",
+		"Orientation changes reported by the devices.",
+		"device",
+	)
+	reconnectsTotal = metrics.NewCounter(
+		"zeid_ble_reconnects_total",
+		"Reconnections to the devices after they disconnected.",
+		"device",
+	)
+	trackingCallsTotal = metrics.NewCounter(
+		"zeid_tracking_calls_total",
+		"Calls starting and stopping tracking on the ZEI API, by device and operation.",
+		"device", "operation",
+	)
+	apiRequestDuration = metrics.NewHistogram(
+		"zei_api_request_duration_seconds",
+		"Duration of the requests to the ZEI API, by operation.",
+		metrics.DefBuckets, "operation",
+	)
+	apiRequestFailures = metrics.NewCounter(
+		"zei_api_request_failures_total",
+		"Failed requests to the ZEI API, by operation and class of error.",
+		"operation", "class",
+	)
+	rpcRequestsTotal = metrics.NewCounter(
+		"zeid_rpc_requests_total",
+		"Requests handled by the zeid API, by method and status code.",
+		"method", "code",
+	)
+	rpcDuration = metrics.NewHistogram(
+		"zeid_rpc_duration_seconds",
+		"Duration of the requests handled by the zeid API, by method.",
+		metrics.DefBuckets, "method",
+	)
+)
+
+// registerMetrics registers the metrics of zeid, along with the current
+// activity of each device as a gauge set to 1 for the tracked activity.
+func registerMetrics(registry *metrics.Registry, devices *zeidsvc.Devices) error {
+	currentActivity := metrics.NewGaugeFunc(
+		"zeid_current_activity",
+		"Activity currently tracked by each device.",
+		[]string{"device", "profile", "activity"},
+		func() []metrics.Sample {
+			var samples []metrics.Sample
+			for _, svc := range devices.All() {
+				samples = append(samples, metrics.Sample{
+					Values: []string{svc.Serial(), svc.Profile(), svc.Current().Name},
+					Value:  1,
+				})
+			}
+			return samples
+		},
+	)
+
+	return registry.Register(
+		flipsTotal, reconnectsTotal, trackingCallsTotal, apiRequestDuration, apiRequestFailures,
+		rpcRequestsTotal, rpcDuration, currentActivity,
+	)
+}
+
+// apiObserver returns the observer of the requests of a device to the ZEI
+// API.
+func apiObserver(serial string) zei.RequestObserver {
+	return func(operation string, duration time.Duration, err error) {
+		apiRequestDuration.Observe(duration.Seconds(), operation)
+
+		if operation == "start_tracking" || operation == "stop_tracking" {
+			trackingCallsTotal.Inc(serial, operation)
+		}
+
+		if err != nil {
+			apiRequestFailures.Inc(operation, zei.ErrorClass(err))
+		}
+	}
+}
+
+// metricsHooks returns twirp server hooks counting the requests and timing
+// them by method.
+func metricsHooks() *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		ResponseSent: func(ctx context.Context) {
+			method, ok := twirp.MethodName(ctx)
+			if !ok {
+				method = "unknown"
+			}
+
+			code, _ := twirp.StatusCode(ctx)
+			rpcRequestsTotal.Inc(method, code)
+
+			if duration, ok := requestDuration(ctx); ok {
+				rpcDuration.Observe(duration.Seconds(), method)
+			}
+		},
+	}
+}
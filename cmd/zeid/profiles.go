@@ -0,0 +1,199 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pauldub/zei/pkg/userdir"
+	"github.com/pauldub/zei/pkg/zeidsvc"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// profilesFile is the declaration of the profiles which can be used, the
+// sides are declared as in layout files of zei.
+type profilesFile struct {
+	Profiles []struct {
+		Name      string `yaml:"name"`
+		APIURL    string `yaml:"api_url"`
+		APIKey    string `yaml:"api_key"`
+		APISecret string `yaml:"api_secret"`
+		Sides     []struct {
+			Side     int64  `yaml:"side"`
+			Activity string `yaml:"activity"`
+			Color    string `yaml:"color"`
+		} `yaml:"sides"`
+	} `yaml:"profiles"`
+}
+
+// readProfiles returns the profiles declared in the file at path, along with
+// the default profile when the file does not declare it.
+func readProfiles(path string) ([]zeidsvc.Profile, error) {
+	profiles := []zeidsvc.Profile{{Name: zeidsvc.DefaultProfile}}
+	if path == "" {
+		return profiles, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file profilesFile
+
+	err = yaml.UnmarshalStrict(b, &file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse profiles")
+	}
+
+	seen := map[string]bool{}
+
+	for _, p := range file.Profiles {
+		if p.Name == "" {
+			return nil, errors.New("profiles must have a name")
+		}
+		if seen[p.Name] {
+			return nil, errors.Errorf("profile %q is declared twice", p.Name)
+		}
+		seen[p.Name] = true
+
+		if (p.APIKey == "") != (p.APISecret == "") {
+			return nil, errors.Errorf("profile %q must have both an API key and secret", p.Name)
+		}
+
+		profile := zeidsvc.Profile{
+			Name:      p.Name,
+			APIURL:    p.APIURL,
+			APIKey:    p.APIKey,
+			APISecret: p.APISecret,
+		}
+
+		for _, s := range p.Sides {
+			profile.Layout = append(profile.Layout, &zeid.LayoutSide{
+				Number:       s.Side,
+				ActivityName: s.Activity,
+				Color:        s.Color,
+			})
+		}
+
+		if p.Name == zeidsvc.DefaultProfile {
+			profiles[0] = profile
+			continue
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// defaultProfileState returns the path of the file the active profile is
+// persisted to, in the configuration directory of the user.
+func defaultProfileState() string {
+	dir, err := userdir.Config()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "profile")
+}
+
+// profileStates persists the active profile of each device to a file, with
+// a line per device made of its serial number and the name of its profile
+// separated by a tab. A line without serial number applies to any device.
+type profileStates struct {
+	path string
+
+	mu sync.Mutex
+}
+
+// get returns the persisted name of the active profile of a device, the
+// default profile when none was persisted.
+func (s *profileStates) get(serial string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states, err := s.read()
+	if err != nil {
+		return "", err
+	}
+
+	if name, ok := states[serial]; ok {
+		return name, nil
+	}
+	if name, ok := states[""]; ok {
+		return name, nil
+	}
+
+	return zeidsvc.DefaultProfile, nil
+}
+
+// set persists the name of the active profile of a device.
+func (s *profileStates) set(serial, name string) error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states, err := s.read()
+	if err != nil {
+		return err
+	}
+	states[serial] = name
+
+	lines := make([]string, 0, len(states))
+	for serial, name := range states {
+		if serial == "" {
+			lines = append(lines, name)
+			continue
+		}
+		lines = append(lines, serial+"\t"+name)
+	}
+	sort.Strings(lines)
+
+	err = os.MkdirAll(filepath.Dir(s.path), 0700)
+	if err != nil {
+		return errors.Wrap(err, "failed to create profile state directory")
+	}
+
+	return ioutil.WriteFile(s.path, []byte(strings.Join(lines, "\n")+"\n"), 0600)
+}
+
+func (s *profileStates) read() (map[string]string, error) {
+	states := map[string]string{}
+	if s.path == "" {
+		return states, nil
+	}
+
+	b, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return states, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		fields := strings.SplitN(line, "\t", 2)
+		if len(fields) == 1 {
+			states[""] = fields[0]
+			continue
+		}
+
+		states[fields[0]] = strings.TrimSpace(fields[1])
+	}
+
+	return states, nil
+}
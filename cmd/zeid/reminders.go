@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/zeidsvc"
+	"github.com/0xAX/notificator"
+	"github.com/pkg/errors"
+)
+
+// reminderRules returns the reminder rules configured by flags.
+func reminderRules() (zeidsvc.ReminderRules, error) {
+	rules := zeidsvc.ReminderRules{
+		LongTracking:   *remindAfter,
+		UntrackedAfter: *untrackedAfter,
+	}
+
+	var err error
+
+	if *endOfDay != "" {
+		rules.StopAtEndOfDay = true
+		rules.EndOfDay, err = clock.ParseTimeOfDay(*endOfDay)
+		if err != nil {
+			return rules, errors.Wrap(err, "invalid end of day")
+		}
+	}
+
+	if *workHours != "" {
+		hours := strings.SplitN(*workHours, "-", 2)
+		if len(hours) != 2 {
+			return rules, errors.Errorf("invalid working hours %q, expected HH:MM-HH:MM", *workHours)
+		}
+
+		rules.HasWorkHours = true
+		rules.WorkStart, err = clock.ParseTimeOfDay(hours[0])
+		if err != nil {
+			return rules, errors.Wrap(err, "invalid working hours")
+		}
+
+		rules.WorkEnd, err = clock.ParseTimeOfDay(hours[1])
+		if err != nil {
+			return rules, errors.Wrap(err, "invalid working hours")
+		}
+	}
+
+	return rules, nil
+}
+
+func notifyReminder(notify *notificator.Notificator, r zeidsvc.Reminder) error {
+	switch r.Kind {
+	case zeidsvc.LongTrackingReminder:
+		return notify.Push(
+			"Still tracking",
+			fmt.Sprintf("%s has been tracked for %s", r.Activity.Name, r.At.Sub(r.Since).Truncate(time.Minute)),
+			"",
+			notificator.UR_NORMAL,
+		)
+	case zeidsvc.EndOfDayReminder:
+		return notify.Push(
+			"Stopping activity",
+			fmt.Sprintf("%s was stopped at the end of the day (%s)", r.Activity.Name, r.At.Local().Format("15:04")),
+			"",
+			notificator.UR_NORMAL,
+		)
+	case zeidsvc.UntrackedReminder:
+		return notify.Push(
+			"Not tracking",
+			fmt.Sprintf("Nothing has been tracked since %s", r.Since.Local().Format("15:04")),
+			"",
+			notificator.UR_NORMAL,
+		)
+	}
+
+	return nil
+}
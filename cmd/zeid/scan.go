@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ble/ble"
+	"github.com/pkg/errors"
+)
+
+// scanTimeout bounds the wait of a runner for its device, it scans again
+// after reconnectDelay.
+const scanTimeout = time.Minute
+
+// dialTimeout bounds the connection to a device once it was found.
+const dialTimeout = 30 * time.Second
+
+// scanner runs a single scan for all the runners waiting for a device, and
+// hands each advertised device to the first runner accepting it. The scan is
+// stopped while the device is dialed, adapters dial one device at a time.
+type scanner struct {
+	mu       sync.Mutex
+	waiters  []*scanWaiter
+	scanning bool
+	cancel   context.CancelFunc
+}
+
+// scanWaiter is a runner waiting for a device.
+type scanWaiter struct {
+	accept func(serial string) bool
+	result chan scanResult
+}
+
+type scanResult struct {
+	conn   ble.Client
+	serial string
+	err    error
+}
+
+// connect connects to the first device accepted by accept, which is called
+// with the serial number of each advertised device, until ctx is done or
+// scanTimeout elapsed.
+func (s *scanner) connect(ctx context.Context, accept func(serial string) bool) (ble.Client, string, error) {
+	w := &scanWaiter{accept: accept, result: make(chan scanResult, 1)}
+
+	s.mu.Lock()
+	s.waiters = append(s.waiters, w)
+	if !s.scanning {
+		s.scanning = true
+		go s.scan()
+	}
+	s.mu.Unlock()
+
+	timeout := time.NewTimer(scanTimeout)
+	defer timeout.Stop()
+
+	var err error
+
+	select {
+	case res := <-w.result:
+		return res.conn, res.serial, res.err
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-timeout.C:
+		err = errors.Errorf("no device found after %s", scanTimeout)
+	}
+
+	if !s.remove(w) {
+		// the device was found meanwhile.
+		res := <-w.result
+		if res.err == nil {
+			res.conn.CancelConnection()
+		}
+	}
+
+	return nil, "", err
+}
+
+// remove stops waiting for a device, it returns false when a device was
+// already handed to the waiter. The scan is stopped when nobody waits.
+func (s *scanner) remove(w *scanWaiter) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, waiter := range s.waiters {
+		if waiter == w {
+			s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+
+			if len(s.waiters) == 0 && s.cancel != nil {
+				s.cancel()
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// claim returns the first waiter accepting the device with the given serial
+// number, which stops waiting.
+func (s *scanner) claim(serial string) *scanWaiter {
+	s.mu.Lock()
+	waiters := append([]*scanWaiter(nil), s.waiters...)
+	s.mu.Unlock()
+
+	// the lock is not held while accepting, the user may be asked.
+	for _, w := range waiters {
+		if w.accept(serial) && s.remove(w) {
+			return w
+		}
+	}
+
+	return nil
+}
+
+// scan scans for devices while runners are waiting for one.
+func (s *scanner) scan() {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), scanTimeout)
+
+		s.mu.Lock()
+		if len(s.waiters) == 0 {
+			s.scanning = false
+			s.cancel = nil
+			s.mu.Unlock()
+			cancel()
+			return
+		}
+		s.cancel = cancel
+		s.mu.Unlock()
+
+		var (
+			// advertisements may still be handled once the scan returned.
+			mu     sync.Mutex
+			done   bool
+			found  ble.Advertisement
+			waiter *scanWaiter
+		)
+
+		// advertisements are repeated so devices ignored by the runners
+		// which waited are seen by the ones which wait now.
+		err := ble.Scan(ctx, true, func(a ble.Advertisement) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if done || waiter != nil {
+				return
+			}
+
+			waiter = s.claim(string(a.ManufacturerData()))
+			if waiter != nil {
+				found = a
+				cancel()
+			}
+		}, isZei)
+		cancel()
+
+		mu.Lock()
+		done = true
+		mu.Unlock()
+
+		if waiter != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+			conn, err := ble.Dial(ctx, found.Addr())
+			cancel()
+
+			waiter.result <- scanResult{conn, string(found.ManufacturerData()), errors.Wrap(err, "failed to dial device")}
+			continue
+		}
+
+		cause := errors.Cause(err)
+		if err != nil && cause != context.Canceled && cause != context.DeadlineExceeded {
+			s.fail(errors.Wrap(err, "failed to scan devices"))
+		}
+	}
+}
+
+// fail hands err to all the waiters.
+func (s *scanner) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, w := range s.waiters {
+		w.result <- scanResult{err: err}
+	}
+	s.waiters = nil
+}
+
+func isZei(a ble.Advertisement) bool {
+	return strings.ToUpper(a.LocalName()) == strings.ToUpper("Timeular ZEI")
+}
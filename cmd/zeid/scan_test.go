@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-ble/ble"
+)
+
+// advertisement is a device advertised by its serial number, which is also
+// its address.
+type advertisement struct {
+	ble.Advertisement
+
+	serial string
+}
+
+func (a advertisement) LocalName() string {
+	return "Timeular ZEI"
+}
+
+func (a advertisement) ManufacturerData() []byte {
+	return []byte(a.serial)
+}
+
+func (a advertisement) Addr() ble.Addr {
+	return ble.NewAddr(a.serial)
+}
+
+type scanClient struct {
+	ble.Client
+
+	addr ble.Addr
+}
+
+func (c scanClient) Addr() ble.Addr {
+	return c.addr
+}
+
+func (c scanClient) CancelConnection() error {
+	return nil
+}
+
+// scanDevice advertises its devices until the scan is canceled, and fails
+// the test when a device is dialed while scanning or dialing.
+type scanDevice struct {
+	ble.Device
+
+	t       *testing.T
+	serials []string
+
+	mu       sync.Mutex
+	scanning bool
+	dialing  bool
+}
+
+func (d *scanDevice) Scan(ctx context.Context, allowDup bool, h ble.AdvHandler) error {
+	d.mu.Lock()
+	d.scanning = true
+	d.mu.Unlock()
+
+	defer func() {
+		d.mu.Lock()
+		d.scanning = false
+		d.mu.Unlock()
+	}()
+
+	for {
+		for _, serial := range d.serials {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Millisecond):
+			}
+
+			h(advertisement{serial: serial})
+		}
+	}
+}
+
+func (d *scanDevice) Dial(ctx context.Context, a ble.Addr) (ble.Client, error) {
+	d.mu.Lock()
+	if d.scanning || d.dialing {
+		d.t.Errorf("dialed %s while scanning or dialing", a)
+	}
+	d.dialing = true
+	d.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	d.mu.Lock()
+	d.dialing = false
+	d.mu.Unlock()
+
+	return scanClient{addr: a}, nil
+}
+
+func TestScannerConnect(t *testing.T) {
+	ble.SetDefaultDevice(&scanDevice{t: t, serials: []string{"c", "b", "a"}})
+	defer ble.SetDefaultDevice(nil)
+
+	var s scanner
+
+	serials := []string{"a", "b"}
+	found := make([]string, len(serials))
+
+	var wg sync.WaitGroup
+	for i, serial := range serials {
+		wg.Add(1)
+		go func(i int, serial string) {
+			defer wg.Done()
+
+			conn, got, err := s.connect(context.Background(), func(advertised string) bool {
+				return advertised == serial
+			})
+			if err != nil {
+				t.Errorf("failed to connect to %s: %v", serial, err)
+				return
+			}
+
+			if conn.Addr().String() != serial {
+				t.Errorf("connected to %s, want %s", conn.Addr(), serial)
+			}
+			found[i] = got
+		}(i, serial)
+	}
+	wg.Wait()
+
+	for i, serial := range serials {
+		if found[i] != serial {
+			t.Errorf("found %q, want %q", found[i], serial)
+		}
+	}
+}
+
+func TestScannerCancel(t *testing.T) {
+	ble.SetDefaultDevice(&scanDevice{t: t, serials: []string{"a"}})
+	defer ble.SetDefaultDevice(nil)
+
+	var s scanner
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, _, err := s.connect(ctx, func(serial string) bool {
+		return false
+	})
+	if err != context.DeadlineExceeded {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// the scan stops once nobody waits for a device.
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.mu.Lock()
+		scanning := s.scanning
+		s.mu.Unlock()
+
+		if !scanning {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("still scanning after 1s")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pauldub/zei/pkg/statusfile"
+	"github.com/pauldub/zei/pkg/zeidsvc"
+	"github.com/pauldub/zei/rpc/zeid"
+)
+
+// writeStatus writes the current activity to the status files at each
+// interval, until ctx is done and the files are removed.
+func writeStatus(ctx context.Context, svc zeidsvc.ZeiSvc, paths []string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := svc.CurrentActivity(ctx, &zeid.CurrentActivityReq{})
+		for _, path := range paths {
+			if err == nil {
+				err = statusfile.Write(path, status)
+			}
+		}
+		if err != nil {
+			logger.Warn("failed to write status file", "device", svc.Serial(), "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, path := range paths {
+				os.Remove(path)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Package clock provides an injectable source of time so time dependent
+// behaviour can be driven explicitly.
+package clock
+
+import (
+	"time"
+)
+
+// Clock tells the time and schedules functions.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+
+	// AfterFunc waits for the duration to elapse and then calls f in its
+	// own goroutine.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a scheduled function call which can be cancelled.
+type Timer interface {
+	// Stop prevents the timer from firing, it returns false if the timer
+	// already fired or was stopped.
+	Stop() bool
+}
+
+// Real is the system clock.
+var Real Clock = realClock{}
+
+// OrReal returns c, or the system clock when c is nil.
+func OrReal(c Clock) Clock {
+	if c == nil {
+		return Real
+	}
+
+	return c
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
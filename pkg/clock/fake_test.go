@@ -0,0 +1,102 @@
+package clock
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2019, time.March, 4, 9, 0, 0, 0, time.UTC)
+
+func TestFakeAdvance(t *testing.T) {
+	c := NewFake(epoch)
+
+	var fired []string
+	at := map[string]time.Time{}
+	schedule := func(name string, d time.Duration) {
+		c.AfterFunc(d, func() {
+			fired = append(fired, name)
+			at[name] = c.Now()
+		})
+	}
+
+	schedule("third", 3*time.Second)
+	schedule("first", time.Second)
+	schedule("second", 2*time.Second)
+	schedule("later", time.Minute)
+
+	c.Advance(2 * time.Second)
+
+	if want := []string{"first", "second"}; !reflect.DeepEqual(fired, want) {
+		t.Fatalf("got %v fired, want %v", fired, want)
+	}
+
+	c.Advance(time.Second)
+
+	if want := []string{"first", "second", "third"}; !reflect.DeepEqual(fired, want) {
+		t.Fatalf("got %v fired, want %v", fired, want)
+	}
+
+	// timers see the time they were scheduled at.
+	for name, d := range map[string]time.Duration{"first": time.Second, "second": 2 * time.Second, "third": 3 * time.Second} {
+		if !at[name].Equal(epoch.Add(d)) {
+			t.Errorf("%s fired at %s, want %s", name, at[name], epoch.Add(d))
+		}
+	}
+
+	if got := c.Since(epoch); got != 3*time.Second {
+		t.Errorf("got %s elapsed, want 3s", got)
+	}
+}
+
+func TestFakeAdvanceSchedulesFromTimers(t *testing.T) {
+	c := NewFake(epoch)
+
+	var fired []time.Time
+	c.AfterFunc(time.Second, func() {
+		fired = append(fired, c.Now())
+
+		c.AfterFunc(time.Second, func() {
+			fired = append(fired, c.Now())
+		})
+	})
+
+	c.Advance(5 * time.Second)
+
+	want := []time.Time{epoch.Add(time.Second), epoch.Add(2 * time.Second)}
+	if !reflect.DeepEqual(fired, want) {
+		t.Errorf("got %v fired, want %v", fired, want)
+	}
+	if !c.Now().Equal(epoch.Add(5 * time.Second)) {
+		t.Errorf("got %s, want %s", c.Now(), epoch.Add(5*time.Second))
+	}
+}
+
+func TestFakeStop(t *testing.T) {
+	c := NewFake(epoch)
+
+	fired := false
+	timer := c.AfterFunc(time.Second, func() {
+		fired = true
+	})
+
+	if !timer.Stop() {
+		t.Error("Stop of a pending timer returned false")
+	}
+	if timer.Stop() {
+		t.Error("Stop of a stopped timer returned true")
+	}
+
+	c.Advance(time.Minute)
+
+	if fired {
+		t.Error("stopped timer fired")
+	}
+
+	timer = c.AfterFunc(time.Second, func() {})
+	c.Advance(time.Second)
+
+	if timer.Stop() {
+		t.Error("Stop of a fired timer returned true")
+	}
+}
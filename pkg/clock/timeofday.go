@@ -0,0 +1,23 @@
+package clock
+
+import (
+	"fmt"
+	"time"
+)
+
+// ParseTimeOfDay parses a "15:04" time of day into the time elapsed since
+// midnight.
+func ParseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// FormatTimeOfDay formats the time elapsed since midnight as a "15:04" time
+// of day.
+func FormatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", d/time.Hour, (d%time.Hour)/time.Minute)
+}
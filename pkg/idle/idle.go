@@ -0,0 +1,227 @@
+// Package idle monitors the idleness of the desktop session over D-Bus.
+package idle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/godbus/dbus"
+	"github.com/pkg/errors"
+)
+
+const (
+	logindDest           = "org.freedesktop.login1"
+	logindPath           = "/org/freedesktop/login1"
+	logindManager        = "org.freedesktop.login1.Manager"
+	logindSession        = "org.freedesktop.login1.Session"
+	propertiesChanged    = "org.freedesktop.DBus.Properties.PropertiesChanged"
+	propertiesInterface  = "org.freedesktop.DBus.Properties"
+	screenSaverInterface = "org.freedesktop.ScreenSaver"
+	gnomeSaverInterface  = "org.gnome.ScreenSaver"
+)
+
+// Event is a change of the session idleness.
+type Event struct {
+	Idle bool
+	// Since is the time at which the session became idle or active.
+	Since time.Time
+}
+
+// WatchLogind sends an event on events each time the IdleHint of a logind
+// session changes. The session is a session ID, "auto" designates the
+// session of the caller. It blocks until ctx is done.
+func WatchLogind(ctx context.Context, conn *dbus.Conn, clk clock.Clock, session string, events chan<- Event) error {
+	clk = clock.OrReal(clk)
+
+	path, err := sessionPath(conn, session)
+	if err != nil {
+		return err
+	}
+
+	signals := make(chan *dbus.Signal, 16)
+	conn.Signal(signals)
+	defer conn.RemoveSignal(signals)
+
+	rule := fmt.Sprintf(
+		"type='signal',sender='%s',interface='%s',member='PropertiesChanged',path='%s'",
+		logindDest, propertiesInterface, path,
+	)
+	err = addMatch(conn, rule)
+	if err != nil {
+		return err
+	}
+	defer removeMatch(conn, rule)
+
+	obj := conn.Object(logindDest, path)
+
+	last, err := sessionIdle(obj, clk)
+	if err != nil {
+		return err
+	}
+	if last.Idle {
+		events <- last
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case sig, ok := <-signals:
+			if !ok {
+				return errors.New("D-Bus connection closed")
+			}
+
+			if sig.Path != path || sig.Name != propertiesChanged || !idleHintChanged(sig) {
+				continue
+			}
+
+			event, err := sessionIdle(obj, clk)
+			if err != nil {
+				return err
+			}
+
+			if event.Idle == last.Idle {
+				continue
+			}
+			last = event
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// WatchScreenSaver sends an event on events each time the screensaver of
+// the session is activated or deactivated. It blocks until ctx is done.
+func WatchScreenSaver(ctx context.Context, conn *dbus.Conn, clk clock.Clock, events chan<- Event) error {
+	clk = clock.OrReal(clk)
+
+	signals := make(chan *dbus.Signal, 16)
+	conn.Signal(signals)
+	defer conn.RemoveSignal(signals)
+
+	for _, iface := range []string{screenSaverInterface, gnomeSaverInterface} {
+		rule := fmt.Sprintf("type='signal',interface='%s',member='ActiveChanged'", iface)
+
+		err := addMatch(conn, rule)
+		if err != nil {
+			return err
+		}
+		defer removeMatch(conn, rule)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case sig, ok := <-signals:
+			if !ok {
+				return errors.New("D-Bus connection closed")
+			}
+
+			if sig.Name != screenSaverInterface+".ActiveChanged" && sig.Name != gnomeSaverInterface+".ActiveChanged" {
+				continue
+			}
+			if len(sig.Body) < 1 {
+				continue
+			}
+
+			active, ok := sig.Body[0].(bool)
+			if !ok {
+				continue
+			}
+
+			select {
+			case events <- Event{Idle: active, Since: clk.Now()}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+func sessionPath(conn *dbus.Conn, session string) (dbus.ObjectPath, error) {
+	if session == "" || session == "auto" {
+		id, err := conn.Object(logindDest, logindPath+"/session/auto").GetProperty(logindSession + ".Id")
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get logind session ID")
+		}
+
+		session, _ = id.Value().(string)
+	}
+
+	var path dbus.ObjectPath
+
+	err := conn.Object(logindDest, logindPath).Call(logindManager+".GetSession", 0, session).Store(&path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get logind session %q", session)
+	}
+
+	return path, nil
+}
+
+func sessionIdle(obj dbus.BusObject, clk clock.Clock) (Event, error) {
+	hint, err := obj.GetProperty(logindSession + ".IdleHint")
+	if err != nil {
+		return Event{}, errors.Wrap(err, "failed to get session IdleHint")
+	}
+
+	idle, _ := hint.Value().(bool)
+	event := Event{Idle: idle, Since: clk.Now()}
+
+	since, err := obj.GetProperty(logindSession + ".IdleSinceHint")
+	if err != nil {
+		return Event{}, errors.Wrap(err, "failed to get session IdleSinceHint")
+	}
+
+	// IdleSinceHint is expressed in microseconds since the epoch.
+	if usec, ok := since.Value().(uint64); ok && usec > 0 {
+		event.Since = time.Unix(0, int64(usec)*int64(time.Microsecond))
+	}
+
+	return event, nil
+}
+
+func idleHintChanged(sig *dbus.Signal) bool {
+	if len(sig.Body) < 3 {
+		return false
+	}
+
+	if iface, _ := sig.Body[0].(string); iface != logindSession {
+		return false
+	}
+
+	if changed, ok := sig.Body[1].(map[string]dbus.Variant); ok {
+		if _, ok := changed["IdleHint"]; ok {
+			return true
+		}
+	}
+
+	if invalidated, ok := sig.Body[2].([]string); ok {
+		for _, name := range invalidated {
+			if name == "IdleHint" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func addMatch(conn *dbus.Conn, rule string) error {
+	err := conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, rule).Err
+	if err != nil {
+		return errors.Wrap(err, "failed to add D-Bus match rule")
+	}
+
+	return nil
+}
+
+func removeMatch(conn *dbus.Conn, rule string) {
+	conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, rule)
+}
@@ -0,0 +1,335 @@
+package idle_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/idle"
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pauldub/zei/pkg/zeidsvc"
+	"github.com/godbus/dbus"
+)
+
+const busConfig = `<!DOCTYPE busconfig PUBLIC "-//freedesktop//DTD D-Bus Bus Configuration 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/busconfig.dtd">
+<busconfig>
+  <type>session</type>
+  <listen>unix:path=%s</listen>
+  <auth>EXTERNAL</auth>
+  <policy context="default">
+    <allow send_destination="*" eavesdrop="true"/>
+    <allow eavesdrop="true"/>
+    <allow own="*"/>
+  </policy>
+</busconfig>
+`
+
+var (
+	epoch  = time.Date(2019, time.March, 4, 9, 0, 0, 0, time.UTC)
+	coding = zei.Activity{ID: "1", Name: "Coding", DeviceSide: 1}
+)
+
+// startBus starts a private bus, stopped by the returned function, and
+// returns its address.
+func startBus(t *testing.T) (string, func()) {
+	t.Helper()
+
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon is not installed")
+	}
+
+	dir, err := ioutil.TempDir("", "zei-idle")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := filepath.Join(dir, "bus.conf")
+	err = ioutil.WriteFile(config, []byte(fmt.Sprintf(busConfig, filepath.Join(dir, "bus"))), 0600)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(daemon, "--config-file="+config, "--nofork", "--nopidfile", "--print-address=1")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+
+	stop := func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+		os.RemoveAll(dir)
+	}
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		stop()
+		t.Fatalf("failed to read the address of the bus: %v", err)
+	}
+
+	return strings.TrimSpace(address), stop
+}
+
+func connect(t *testing.T, address string) *dbus.Conn {
+	t.Helper()
+
+	conn, err := dbus.Dial(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = conn.Auth(nil)
+	if err == nil {
+		err = conn.Hello()
+	}
+	if err != nil {
+		conn.Close()
+		t.Fatal(err)
+	}
+
+	return conn
+}
+
+// idleSvc is a service tracking the activities it is switched to.
+type idleSvc struct {
+	zeidsvc.ZeiSvc
+
+	mu      sync.Mutex
+	current zei.Activity
+	since   time.Time
+	gap     *zeidsvc.IdleGap
+}
+
+func (s *idleSvc) Current() zei.Activity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current
+}
+
+func (s *idleSvc) StartTime() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.since
+}
+
+func (s *idleSvc) Switch(ctx context.Context, a zei.Activity, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.current = a
+	s.since = at
+
+	return nil
+}
+
+func (s *idleSvc) SetIdleGap(gap zeidsvc.IdleGap) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.gap = &gap
+}
+
+func (s *idleSvc) assertTracking(t *testing.T, name string, since time.Time) {
+	t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current.Name != name || !s.since.Equal(since) {
+		t.Fatalf("tracking %s since %s, want %s since %s", s.current.Name, s.since, name, since)
+	}
+}
+
+// nextEvent emits signals until the watcher reports the wanted idleness, the
+// first signals may be sent before the watcher listens to them.
+func nextEvent(t *testing.T, events <-chan idle.Event, emit func() error, want bool) idle.Event {
+	t.Helper()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		err := emit()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case event := <-events:
+			if event.Idle == want {
+				return event
+			}
+		case <-time.After(50 * time.Millisecond):
+		case <-deadline:
+			t.Fatalf("no event with idle %t after 5s", want)
+		}
+	}
+}
+
+// testStopper checks an IdleStopper fed by a watcher, emit makes the session
+// idle or active.
+func testStopper(t *testing.T, c *clock.Fake, events <-chan idle.Event, emit func(idling bool) error) {
+	svc := &idleSvc{current: coding, since: epoch.Add(-time.Hour)}
+
+	var stopped, resumed int
+	stopper := zeidsvc.NewIdleStopper(context.Background(), svc, zeidsvc.IdleOptions{
+		Timeout: 5 * time.Minute,
+		Clock:   c,
+		OnStop: func(a zei.Activity, since time.Time) {
+			stopped++
+		},
+		OnResume: func(gap zeidsvc.IdleGap) {
+			resumed++
+		},
+		OnError: func(err error) {
+			t.Errorf("unexpected error: %v", err)
+		},
+	})
+	defer stopper.Stop()
+
+	event := nextEvent(t, events, func() error { return emit(true) }, true)
+	stopper.Idle(event.Idle, event.Since)
+
+	c.Advance(4 * time.Minute)
+	svc.assertTracking(t, coding.Name, epoch.Add(-time.Hour))
+
+	// tracking is stopped when the session became idle.
+	c.Advance(time.Minute)
+	svc.assertTracking(t, "Idle", event.Since)
+
+	idleSince := event.Since
+	c.Advance(20 * time.Minute)
+
+	event = nextEvent(t, events, func() error { return emit(false) }, false)
+	stopper.Idle(event.Idle, event.Since)
+
+	svc.assertTracking(t, coding.Name, event.Since)
+
+	if stopped != 1 || resumed != 1 {
+		t.Errorf("got %d stops and %d resumes, want 1 of each", stopped, resumed)
+	}
+
+	if svc.gap == nil || svc.gap.Activity.ID != coding.ID || !svc.gap.From.Equal(idleSince) || !svc.gap.To.Equal(event.Since) {
+		t.Errorf("got idle gap %+v, want %s from %s to %s", svc.gap, coding.Name, idleSince, event.Since)
+	}
+}
+
+func TestWatchScreenSaver(t *testing.T) {
+	address, stop := startBus(t)
+	defer stop()
+
+	watcher, emitter := connect(t, address), connect(t, address)
+	defer watcher.Close()
+	defer emitter.Close()
+
+	c := clock.NewFake(epoch)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan idle.Event)
+	go idle.WatchScreenSaver(ctx, watcher, c, events)
+
+	testStopper(t, c, events, func(idling bool) error {
+		return emitter.Emit("/org/freedesktop/ScreenSaver", "org.freedesktop.ScreenSaver.ActiveChanged", idling)
+	})
+}
+
+// logindSession is a logind session exposing its idle hint.
+type logindSession struct {
+	mu        sync.Mutex
+	idle      bool
+	idleSince time.Time
+}
+
+func (s *logindSession) Get(iface, property string) (dbus.Variant, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch property {
+	case "IdleHint":
+		return dbus.MakeVariant(s.idle), nil
+	case "IdleSinceHint":
+		return dbus.MakeVariant(uint64(s.idleSince.UnixNano() / int64(time.Microsecond))), nil
+	}
+
+	return dbus.Variant{}, dbus.NewError("org.freedesktop.DBus.Error.UnknownProperty", []interface{}{property})
+}
+
+func (s *logindSession) set(idling bool, since time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.idle, s.idleSince = idling, since
+}
+
+type logindManager struct{}
+
+func (logindManager) GetSession(id string) (dbus.ObjectPath, *dbus.Error) {
+	return dbus.ObjectPath("/org/freedesktop/login1/session/" + id), nil
+}
+
+func TestWatchLogind(t *testing.T) {
+	address, stop := startBus(t)
+	defer stop()
+
+	watcher, logind := connect(t, address), connect(t, address)
+	defer watcher.Close()
+	defer logind.Close()
+
+	const path = dbus.ObjectPath("/org/freedesktop/login1/session/c1")
+
+	session := &logindSession{}
+	err := logind.Export(session, path, "org.freedesktop.DBus.Properties")
+	if err == nil {
+		err = logind.Export(logindManager{}, "/org/freedesktop/login1", "org.freedesktop.login1.Manager")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := logind.RequestName("org.freedesktop.login1", dbus.NameFlagDoNotQueue)
+	if err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatalf("failed to own the logind name: %v", err)
+	}
+
+	c := clock.NewFake(epoch)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan idle.Event)
+	go idle.WatchLogind(ctx, watcher, c, "c1", events)
+
+	// the session became idle before the hint changed.
+	idleSince := epoch.Add(-30 * time.Second)
+
+	testStopper(t, c, events, func(idling bool) error {
+		if idling {
+			session.set(true, idleSince)
+		} else {
+			session.set(false, c.Now())
+		}
+
+		return logind.Emit(path, "org.freedesktop.DBus.Properties.PropertiesChanged",
+			"org.freedesktop.login1.Session", map[string]dbus.Variant{"IdleHint": dbus.MakeVariant(idling)}, []string{},
+		)
+	})
+}
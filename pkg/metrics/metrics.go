@@ -0,0 +1,395 @@
+// Package metrics implements counters, gauges and histograms exposed in the
+// Prometheus text format, version 0.0.4.
+//
+// Metrics are recorded as soon as they are created, and written once
+// registered to a registry.
+//
+// The Prometheus client library is not vendored, it brings client_model,
+// common, procfs, perks and golang_protobuf_extensions along for the few
+// metrics of zeid. The output is tested against the format instead.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// DefBuckets are the default buckets of histograms, suited to durations in
+// seconds.
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Metric is a counter, a gauge or a histogram.
+type Metric interface {
+	name() string
+	validate() error
+	write(w io.Writer)
+}
+
+// Registry holds the metrics written together.
+type Registry struct {
+	mu      sync.Mutex
+	metrics map[string]Metric
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{metrics: map[string]Metric{}}
+}
+
+// Register adds metrics to the registry, names must be unique and valid
+// metric and label names.
+func (r *Registry) Register(metrics ...Metric) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, m := range metrics {
+		err := m.validate()
+		if err != nil {
+			return err
+		}
+
+		if _, ok := r.metrics[m.name()]; ok {
+			return errors.Errorf("metric %s is registered twice", m.name())
+		}
+
+		r.metrics[m.name()] = m
+	}
+
+	return nil
+}
+
+// Handler returns an HTTP handler writing the registered metrics.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		r.WriteText(w)
+	})
+}
+
+// WriteText writes the registered metrics to w in the text format, sorted by
+// name.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	metrics := make([]Metric, 0, len(r.metrics))
+	for _, m := range r.metrics {
+		metrics = append(metrics, m)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].name() < metrics[j].name()
+	})
+
+	buf := bufio.NewWriter(w)
+	for _, m := range metrics {
+		m.write(buf)
+	}
+
+	return buf.Flush()
+}
+
+// desc describes a metric and the names of its labels.
+type desc struct {
+	metricName string
+	help       string
+	kind       string
+	labels     []string
+}
+
+var (
+	metricNameRE = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNameRE  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+func (d *desc) name() string {
+	return d.metricName
+}
+
+func (d *desc) validate() error {
+	if !metricNameRE.MatchString(d.metricName) {
+		return errors.Errorf("invalid metric name %q", d.metricName)
+	}
+
+	seen := map[string]bool{}
+	for _, label := range d.labels {
+		switch {
+		case !labelNameRE.MatchString(label) || strings.HasPrefix(label, "__"):
+			return errors.Errorf("invalid label name %q of metric %s", label, d.metricName)
+		case d.kind == "histogram" && label == "le":
+			return errors.Errorf("label le of histogram %s is reserved for buckets", d.metricName)
+		case seen[label]:
+			return errors.Errorf("label %s of metric %s is declared twice", label, d.metricName)
+		}
+		seen[label] = true
+	}
+
+	return nil
+}
+
+func (d *desc) writeHeader(w io.Writer) {
+	help := strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(d.help)
+
+	fmt.Fprintf(w, "# HELP %s %s\n", d.metricName, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.metricName, d.kind)
+}
+
+// key identifies the series with the given label values.
+func (d *desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", d.metricName, len(d.labels), len(values)))
+	}
+
+	return strings.Join(values, "\xff")
+}
+
+// writeSample writes a sample of the series with the given label values,
+// extra label pairs are appended to the labels of the metric.
+func (d *desc) writeSample(w io.Writer, suffix string, values []string, value float64, extra ...string) {
+	var pairs []string
+
+	for i, label := range d.labels {
+		pairs = append(pairs, labelPair(label, values[i]))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, labelPair(extra[i], extra[i+1]))
+	}
+
+	var labels string
+	if len(pairs) > 0 {
+		labels = fmt.Sprintf("{%s}", strings.Join(pairs, ","))
+	}
+
+	fmt.Fprintf(w, "%s%s%s %s\n", d.metricName, suffix, labels, formatFloat(value))
+}
+
+func labelPair(name, value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+
+	return fmt.Sprintf(`%s="%s"`, name, value)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// series is the value of a metric for some label values.
+type series struct {
+	values []string
+	value  float64
+}
+
+// seriesSet holds the series of a counter or a gauge.
+type seriesSet struct {
+	desc
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+func (s *seriesSet) update(values []string, f func(v float64) float64) {
+	key := s.key(values)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ser, ok := s.series[key]
+	if !ok {
+		ser = &series{values: append([]string(nil), values...)}
+		s.series[key] = ser
+	}
+
+	ser.value = f(ser.value)
+}
+
+func (s *seriesSet) write(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.writeHeader(w)
+
+	keys := make([]string, 0, len(s.series))
+	for key := range s.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		ser := s.series[key]
+		s.writeSample(w, "", ser.values, ser.value)
+	}
+}
+
+// Counter is a value which only increases, partitioned by labels.
+type Counter struct {
+	seriesSet
+}
+
+// NewCounter returns a counter with the given label names.
+func NewCounter(name, help string, labels ...string) *Counter {
+	return &Counter{seriesSet{
+		desc:   desc{name, help, "counter", labels},
+		series: map[string]*series{},
+	}}
+}
+
+// Inc increments the counter of the given label values.
+func (c *Counter) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Add adds delta, which must not be negative, to the counter of the given
+// label values.
+func (c *Counter) Add(delta float64, values ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("metrics: counter %s cannot decrease", c.metricName))
+	}
+
+	c.update(values, func(v float64) float64 { return v + delta })
+}
+
+// Gauge is a value which can go up and down, partitioned by labels.
+type Gauge struct {
+	seriesSet
+}
+
+// NewGauge returns a gauge with the given label names.
+func NewGauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{seriesSet{
+		desc:   desc{name, help, "gauge", labels},
+		series: map[string]*series{},
+	}}
+}
+
+// Set sets the gauge of the given label values.
+func (g *Gauge) Set(value float64, values ...string) {
+	g.update(values, func(float64) float64 { return value })
+}
+
+// Sample is the value of a series of a gauge func.
+type Sample struct {
+	Values []string
+	Value  float64
+}
+
+// GaugeFunc is a gauge whose series are computed each time the metrics are
+// written.
+type GaugeFunc struct {
+	desc
+
+	fn func() []Sample
+}
+
+// NewGaugeFunc returns a gauge with the given label names, whose series are
+// returned by fn.
+func NewGaugeFunc(name, help string, labels []string, fn func() []Sample) *GaugeFunc {
+	return &GaugeFunc{
+		desc: desc{name, help, "gauge", labels},
+		fn:   fn,
+	}
+}
+
+func (g *GaugeFunc) write(w io.Writer) {
+	g.writeHeader(w)
+
+	for _, sample := range g.fn() {
+		g.key(sample.Values)
+		g.writeSample(w, "", sample.Values, sample.Value)
+	}
+}
+
+// Histogram counts observations in buckets, partitioned by labels.
+type Histogram struct {
+	desc
+
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	values []string
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogram returns a histogram with the given upper bounds of its buckets
+// and label names.
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &Histogram{
+		desc:    desc{name, help, "histogram", labels},
+		buckets: buckets,
+		series:  map[string]*histogramSeries{},
+	}
+}
+
+// Observe adds an observation to the histogram of the given label values.
+func (h *Histogram) Observe(value float64, values ...string) {
+	key := h.key(values)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ser, ok := h.series[key]
+	if !ok {
+		ser = &histogramSeries{
+			values: append([]string(nil), values...),
+			counts: make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = ser
+	}
+
+	for i, bound := range h.buckets {
+		if value <= bound {
+			ser.counts[i]++
+		}
+	}
+	ser.count++
+	ser.sum += value
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.writeHeader(w)
+
+	keys := make([]string, 0, len(h.series))
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		ser := h.series[key]
+
+		for i, bound := range h.buckets {
+			h.writeSample(w, "_bucket", ser.values, float64(ser.counts[i]), "le", formatFloat(bound))
+		}
+		h.writeSample(w, "_bucket", ser.values, float64(ser.count), "le", "+Inf")
+		h.writeSample(w, "_sum", ser.values, ser.sum)
+		h.writeSample(w, "_count", ser.values, float64(ser.count))
+	}
+}
@@ -0,0 +1,338 @@
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRegistryWriteText(t *testing.T) {
+	flips := NewCounter("flips_total", "Flips of the device.", "device")
+	flips.Inc("Z1")
+	flips.Add(2, "Z1")
+	flips.Inc(`Z"2\`)
+
+	battery := NewGauge("battery_level", "Battery level,\nin percent.")
+	battery.Set(80)
+	battery.Set(75)
+
+	current := NewGaugeFunc("current_activity", "Current activity.", []string{"activity"}, func() []Sample {
+		return []Sample{{Values: []string{"Coding"}, Value: 1}}
+	})
+
+	latency := NewHistogram("latency_seconds", "Latency.", []float64{1, 0.1}, "operation")
+	latency.Observe(0.05, "sign_in")
+	latency.Observe(0.5, "sign_in")
+	latency.Observe(2, "sign_in")
+
+	registry := NewRegistry()
+
+	err := registry.Register(flips, battery, current, latency)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+
+	err = registry.WriteText(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		`# HELP battery_level Battery level,\nin percent.`,
+		`# TYPE battery_level gauge`,
+		`battery_level 75`,
+		`# HELP current_activity Current activity.`,
+		`# TYPE current_activity gauge`,
+		`current_activity{activity="Coding"} 1`,
+		`# HELP flips_total Flips of the device.`,
+		`# TYPE flips_total counter`,
+		`flips_total{device="Z\"2\\"} 1`,
+		`flips_total{device="Z1"} 3`,
+		`# HELP latency_seconds Latency.`,
+		`# TYPE latency_seconds histogram`,
+		`latency_seconds_bucket{operation="sign_in",le="0.1"} 1`,
+		`latency_seconds_bucket{operation="sign_in",le="1"} 2`,
+		`latency_seconds_bucket{operation="sign_in",le="+Inf"} 3`,
+		`latency_seconds_sum{operation="sign_in"} 2.55`,
+		`latency_seconds_count{operation="sign_in"} 3`,
+		``,
+	}, "\n")
+
+	if got := b.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	checkExposition(t, b.String())
+}
+
+func TestRegistryExposition(t *testing.T) {
+	requests := NewCounter("http_requests_total", "Requests, by \\path\\\nand code.", "path", "code")
+	requests.Inc("/", "200")
+	requests.Inc(`/"quoted"`, "404")
+	requests.Inc("/multi\nline\\", "500")
+	requests.Inc("", "200")
+
+	temperature := NewGauge("temperature_celsius", "Temperature.")
+	temperature.Set(-12.5)
+
+	limits := NewGauge("limits", "Limits.", "kind")
+	limits.Set(math.Inf(1), "upper")
+	limits.Set(math.Inf(-1), "lower")
+	limits.Set(1e-9, "epsilon")
+
+	latency := NewHistogram("rpc_duration_seconds", "Latency.", DefBuckets, "method")
+	latency.Observe(0.2, "get")
+	latency.Observe(20, "get")
+	latency.Observe(0.001, "put")
+
+	unused := NewHistogram("unused_seconds", "Never observed.", DefBuckets)
+
+	registry := NewRegistry()
+
+	err := registry.Register(requests, temperature, limits, latency, unused)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+
+	err = registry.WriteText(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkExposition(t, b.String())
+}
+
+func TestRegistryRegisterInvalid(t *testing.T) {
+	cases := []struct {
+		name   string
+		metric Metric
+	}{
+		{"metric name", NewCounter("zeid-flips", "Flips.")},
+		{"metric name starting with a digit", NewGauge("1st", "First.")},
+		{"label name", NewCounter("flips_total", "Flips.", "device:serial")},
+		{"reserved label name", NewGauge("battery", "Battery.", "__name")},
+		{"duplicate label", NewCounter("flips_total", "Flips.", "device", "device")},
+		{"histogram le label", NewHistogram("latency_seconds", "Latency.", DefBuckets, "le")},
+	}
+
+	for _, c := range cases {
+		err := NewRegistry().Register(c.metric)
+		if err == nil {
+			t.Errorf("%s: registered an invalid metric", c.name)
+		}
+	}
+}
+
+func TestRegistryRegisterTwice(t *testing.T) {
+	registry := NewRegistry()
+
+	err := registry.Register(NewCounter("flips_total", "Flips."))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = registry.Register(NewGauge("flips_total", "Flips."))
+	if err == nil {
+		t.Error("registered two metrics with the same name")
+	}
+}
+
+func TestRegistryHandler(t *testing.T) {
+	flips := NewCounter("flips_total", "Flips.")
+	flips.Inc()
+
+	registry := NewRegistry()
+
+	err := registry.Register(flips)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if ct := res.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("got content type %q, want the text format", ct)
+	}
+	if !strings.Contains(res.Body.String(), "flips_total 1\n") {
+		t.Errorf("got body %q, want the counter", res.Body.String())
+	}
+}
+
+var (
+	expositionSampleRE = regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*)(\{.*\})? (\S+)$`)
+	expositionLabelRE  = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)="((?:[^"\\\n]|\\[\\"n])*)"(,|\})`)
+	expositionTypes    = map[string]bool{"counter": true, "gauge": true, "histogram": true, "summary": true, "untyped": true}
+)
+
+// checkExposition checks that text follows the Prometheus text format,
+// https://prometheus.io/docs/instrumenting/exposition_formats/.
+func checkExposition(t *testing.T, text string) {
+	t.Helper()
+
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		t.Error("the last line doesn't end with a line feed")
+	}
+
+	var (
+		types   = map[string]string{}
+		helps   = map[string]bool{}
+		sampled = map[string]bool{}
+		series  = map[string]bool{}
+		family  string
+		// buckets are the upper bounds and counts of the histogram
+		// series, by name and labels without le.
+		buckets = map[string][][2]float64{}
+		counts  = map[string]float64{}
+	)
+
+	for i, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		if strings.HasPrefix(line, "#") {
+			fields := strings.SplitN(line, " ", 4)
+			if len(fields) < 4 {
+				t.Errorf("line %d: comment %q has no name and value", i, line)
+				continue
+			}
+
+			name := fields[2]
+
+			switch fields[1] {
+			case "HELP":
+				if helps[name] {
+					t.Errorf("line %d: second HELP of %s", i, name)
+				}
+				if strings.Contains(strings.NewReplacer(`\\`, "", `\n`, "").Replace(fields[3]), `\`) {
+					t.Errorf("line %d: HELP of %s has invalid escapes", i, name)
+				}
+				helps[name] = true
+			case "TYPE":
+				if _, ok := types[name]; ok || sampled[name] {
+					t.Errorf("line %d: TYPE of %s is not the first", i, name)
+				}
+				if !expositionTypes[fields[3]] {
+					t.Errorf("line %d: unknown type %q", i, fields[3])
+				}
+				types[name] = fields[3]
+			}
+			continue
+		}
+
+		m := expositionSampleRE.FindStringSubmatch(line)
+		if m == nil {
+			t.Errorf("line %d: invalid sample %q", i, line)
+			continue
+		}
+
+		name, value := m[1], m[3]
+
+		labels, le, ok := parseExpositionLabels(m[2])
+		if !ok {
+			t.Errorf("line %d: invalid labels %q", i, m[2])
+			continue
+		}
+
+		v, err := parseExpositionValue(value)
+		if err != nil {
+			t.Errorf("line %d: invalid value %q", i, value)
+			continue
+		}
+
+		key := name + m[2]
+		if series[key] {
+			t.Errorf("line %d: series %s is repeated", i, key)
+		}
+		series[key] = true
+
+		// samples of a family are written together.
+		sampleFamily := name
+		for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+			if base := strings.TrimSuffix(name, suffix); base != name && types[base] == "histogram" {
+				sampleFamily = base
+
+				switch suffix {
+				case "_bucket":
+					bound, err := parseExpositionValue(le)
+					if err != nil {
+						t.Errorf("line %d: invalid le %q", i, le)
+					}
+					buckets[base+labels] = append(buckets[base+labels], [2]float64{bound, v})
+				case "_count":
+					counts[base+labels] = v
+				}
+			}
+		}
+
+		if sampleFamily != family {
+			if sampled[sampleFamily] {
+				t.Errorf("line %d: samples of %s are not written together", i, sampleFamily)
+			}
+			family = sampleFamily
+		}
+		sampled[sampleFamily] = true
+	}
+
+	for key, bs := range buckets {
+		for j := 1; j < len(bs); j++ {
+			if bs[j][0] <= bs[j-1][0] || bs[j][1] < bs[j-1][1] {
+				t.Errorf("buckets of %s are not cumulative: %v", key, bs)
+			}
+		}
+
+		last := bs[len(bs)-1]
+		if !math.IsInf(last[0], 1) || last[1] != counts[key] {
+			t.Errorf("last bucket of %s is %v, want +Inf with the count %v", key, last, counts[key])
+		}
+	}
+}
+
+// parseExpositionLabels returns the labels of a sample without le, and the
+// value of le.
+func parseExpositionLabels(s string) (labels, le string, ok bool) {
+	if s == "" {
+		return "", "", true
+	}
+
+	var pairs []string
+
+	for rest := s[1:]; rest != ""; {
+		m := expositionLabelRE.FindStringSubmatch(rest)
+		if m == nil {
+			return "", "", false
+		}
+		rest = rest[len(m[0]):]
+
+		if m[1] == "le" {
+			le = m[2]
+		} else {
+			pairs = append(pairs, m[1]+"="+m[2])
+		}
+
+		if m[3] == "}" && rest != "" {
+			return "", "", false
+		}
+	}
+
+	return strings.Join(pairs, ","), le, true
+}
+
+func parseExpositionValue(s string) (float64, error) {
+	switch s {
+	case "+Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
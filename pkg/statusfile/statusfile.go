@@ -0,0 +1,91 @@
+// Package statusfile shares the current activity of zeid through a file, so
+// status bars and prompts can render it without issuing an RPC.
+package statusfile
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/pkg/errors"
+)
+
+// DefaultPath returns the path of the status file in the runtime directory
+// of the user.
+func DefaultPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "zei", "status.json")
+	}
+
+	return filepath.Join(os.TempDir(), fmt.Sprintf("zei-%d", os.Getuid()), "status.json")
+}
+
+// DevicePath returns the path of the status file of the device with the
+// given serial number, next to the status file at path.
+func DevicePath(path, serial string) string {
+	if serial == "" {
+		return path
+	}
+
+	ext := filepath.Ext(path)
+
+	return fmt.Sprintf("%s-%s%s", strings.TrimSuffix(path, ext), serial, ext)
+}
+
+// Write replaces the status file at path with the current activity.
+func Write(path string, status *zeid.CurrentActivityResp) error {
+	dir := filepath.Dir(path)
+
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return errors.Wrap(err, "failed to create status directory")
+	}
+
+	f, err := ioutil.TempFile(dir, ".status")
+	if err != nil {
+		return errors.Wrap(err, "failed to create status file")
+	}
+	defer os.Remove(f.Name())
+
+	err = (&jsonpb.Marshaler{}).Marshal(f, status)
+	if err != nil {
+		f.Close()
+		return errors.Wrap(err, "failed to encode status")
+	}
+
+	err = f.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to write status file")
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// Read returns the current activity of the status file at path, along with
+// the time it was written.
+func Read(path string) (*zeid.CurrentActivityResp, time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var status zeid.CurrentActivityResp
+
+	err = jsonpb.Unmarshal(bytes.NewReader(b), &status)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "failed to decode status")
+	}
+
+	return &status, info.ModTime(), nil
+}
@@ -0,0 +1,42 @@
+// Package userdir locates the directories of zei in the directories of the
+// user.
+package userdir
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// Config returns the configuration directory of zei, in the configuration
+// directory of the user.
+func Config() (string, error) {
+	var dir string
+
+	switch runtime.GOOS {
+	case "windows":
+		dir = os.Getenv("AppData")
+		if dir == "" {
+			return "", errors.New("%AppData% is not defined")
+		}
+	case "darwin":
+		dir = os.Getenv("HOME")
+		if dir == "" {
+			return "", errors.New("$HOME is not defined")
+		}
+		dir = filepath.Join(dir, "Library", "Application Support")
+	default:
+		dir = os.Getenv("XDG_CONFIG_HOME")
+		if dir == "" {
+			dir = os.Getenv("HOME")
+			if dir == "" {
+				return "", errors.New("neither $XDG_CONFIG_HOME nor $HOME are defined")
+			}
+			dir = filepath.Join(dir, ".config")
+		}
+	}
+
+	return filepath.Join(dir, "zei"), nil
+}
@@ -0,0 +1,50 @@
+package userdir
+
+import (
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestConfig(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skipf("the configuration directory is not defined by XDG on %s", runtime.GOOS)
+	}
+
+	defer os.Setenv("XDG_CONFIG_HOME", os.Getenv("XDG_CONFIG_HOME"))
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+
+	cases := []struct {
+		name       string
+		configHome string
+		home       string
+		want       string
+		err        bool
+	}{
+		{name: "XDG_CONFIG_HOME", configHome: "/xdg", home: "/home/zei", want: "/xdg/zei"},
+		{name: "HOME", home: "/home/zei", want: "/home/zei/.config/zei"},
+		{name: "undefined", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			os.Setenv("XDG_CONFIG_HOME", c.configHome)
+			os.Setenv("HOME", c.home)
+
+			dir, err := Config()
+			if c.err {
+				if err == nil {
+					t.Errorf("got %s, want an error", dir)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if dir != c.want {
+				t.Errorf("got %s, want %s", dir, c.want)
+			}
+		})
+	}
+}
@@ -5,30 +5,144 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 )
 
 const (
-	zeiAPIBaseURL = "https://api.timeular.com/api/v2"
-	TimeFormat    = "2006-01-02T15:04:05.000"
+	// DefaultBaseURL is the base URL of the Timeular API.
+	DefaultBaseURL = "https://api.timeular.com/api/v2"
+
+	// TimeFormat is the layout of times exchanged with the ZEI API, they
+	// carry no zone and are always expressed in UTC.
+	TimeFormat = "2006-01-02T15:04:05.000"
 )
 
-func apiURL(path string) string {
-	return fmt.Sprintf("%s/%s", zeiAPIBaseURL, path)
+func (c *Client) apiURL(path string) string {
+	return fmt.Sprintf("%s/%s", c.baseURL, path)
+}
+
+// FormatTime formats t as expected by the ZEI API.
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(TimeFormat)
+}
+
+// ParseTime parses a time returned by the ZEI API, the returned time is
+// in UTC.
+func ParseTime(value string) (time.Time, error) {
+	return time.ParseInLocation(TimeFormat, value, time.UTC)
 }
 
 // Client is a Zei API client.
 type Client struct {
-	http *http.Client
+	http    *http.Client
+	baseURL string
+	observe RequestObserver
 }
 
-// NewClient returns an initialized client.
+// RequestObserver is called once each request to the API is done, with its
+// operation, its duration and its error if any.
+type RequestObserver func(operation string, duration time.Duration, err error)
+
+// NewClient returns an initialized client of the Timeular API.
 func NewClient() *Client {
+	return NewClientWithBaseURL(DefaultBaseURL)
+}
+
+// NewClientWithBaseURL returns an initialized client of a backend
+// compatible with the Timeular API.
+func NewClientWithBaseURL(baseURL string) *Client {
 	return &Client{
 		// FIXME: initialize the http client properly
-		http: &http.Client{},
+		http:    &http.Client{},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// SetObserver sets the function observing the requests of the client, it
+// must be set before the client is used.
+func (c *Client) SetObserver(observe RequestObserver) {
+	c.observe = observe
+}
+
+// StatusError is returned when the API answers with an unsuccessful status.
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("ZEI API answered with status %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("ZEI API answered with status %d: %s", e.StatusCode, e.Message)
+}
+
+// IsUnauthorized returns whether err is the answer of the API to invalid
+// credentials or access token.
+func IsUnauthorized(err error) bool {
+	e, ok := err.(*StatusError)
+	return ok && (e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden)
+}
+
+// ErrorClass classifies err by its cause: "unauthorized", "client" or
+// "server" for errors answered by the API, "timeout" or "network" otherwise.
+func ErrorClass(err error) string {
+	if e, ok := err.(*StatusError); ok {
+		switch {
+		case IsUnauthorized(e):
+			return "unauthorized"
+		case e.StatusCode >= http.StatusInternalServerError:
+			return "server"
+		default:
+			return "client"
+		}
 	}
+
+	if e, ok := err.(net.Error); ok && e.Timeout() {
+		return "timeout"
+	}
+
+	return "network"
+}
+
+// do sends req for the given operation, and reports it to the observer.
+func (c *Client) do(operation string, req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	res, err := c.send(req)
+	if c.observe != nil {
+		c.observe(operation, time.Since(start), err)
+	}
+
+	return res, err
+}
+
+// send sends req, the responses with an unsuccessful status are turned into
+// a StatusError.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		defer res.Body.Close()
+
+		body, _ := ioutil.ReadAll(io.LimitReader(res.Body, 512))
+
+		return nil, &StatusError{
+			StatusCode: res.StatusCode,
+			Message:    strings.TrimSpace(string(body)),
+		}
+	}
+
+	return res, nil
 }
 
 type developerSignInRequest struct {
@@ -58,12 +172,12 @@ func (c *Client) DeveloperSignIn(
 		return "", err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, apiURL("/developer/sign-in"), bytes.NewReader(reqBody))
+	req, err := http.NewRequest(http.MethodPost, c.apiURL("/developer/sign-in"), bytes.NewReader(reqBody))
 	if err != nil {
 		return "", err
 	}
 
-	res, err := c.http.Do(req)
+	res, err := c.do("sign_in", req)
 	if err != nil {
 		return "", err
 	}
@@ -95,22 +209,18 @@ func (c *Client) AssignActivity(
 	activityID string,
 	deviceSide int,
 ) (*Activity, error) {
-	req, err := http.NewRequest(http.MethodPost, apiURL(fmt.Sprintf("/activities/%s/device-side/%d", activityID, deviceSide)), nil)
+	req, err := http.NewRequest(http.MethodPost, c.apiURL(fmt.Sprintf("/activities/%s/device-side/%d", activityID, deviceSide)), nil)
 	if err != nil {
 		return nil, err
 	}
 	c.authorize(req, token)
 
-	res, err := c.http.Do(req)
+	res, err := c.do("assign_activity", req)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode >= http.StatusBadRequest {
-		return nil, fmt.Errorf("ZEI API response status %d", res.StatusCode)
-	}
-
 	var activity Activity
 	err = json.NewDecoder(res.Body).Decode(&activity)
 	if err != nil {
@@ -135,14 +245,14 @@ func (c *Client) Activities(
 		err         error
 	)
 
-	req, err := http.NewRequest(http.MethodGet, apiURL("/activities"), nil)
+	req, err := http.NewRequest(http.MethodGet, c.apiURL("/activities"), nil)
 	if err != nil {
 		return activities, err
 	}
 	c.authorize(req, accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	res, err := c.http.Do(req)
+	res, err := c.do("activities", req)
 	if err != nil {
 		return activities, err
 	}
@@ -162,6 +272,11 @@ type Tracking struct {
 	StartedAt string   `json:"startedAt"`
 }
 
+// StartTime returns the time at which the tracking started.
+func (t *Tracking) StartTime() (time.Time, error) {
+	return ParseTime(t.StartedAt)
+}
+
 type currentTrackingResponse struct {
 	CurrentTracking Tracking
 }
@@ -178,7 +293,7 @@ func (c *Client) CurrentTracking(
 
 	req, err := http.NewRequest(
 		http.MethodGet,
-		apiURL("/tracking"),
+		c.apiURL("/tracking"),
 		nil,
 	)
 	if err != nil {
@@ -186,7 +301,7 @@ func (c *Client) CurrentTracking(
 	}
 	c.authorize(req, accessToken)
 
-	res, err := c.http.Do(req)
+	res, err := c.do("current_tracking", req)
 	if err != nil {
 		return nil, err
 	}
@@ -212,7 +327,7 @@ func (c *Client) StartTracking(
 	startedAt time.Time,
 ) error {
 	reqBody, err := json.Marshal(&startTrackingRequest{
-		StartedAt: startedAt.UTC().Format(TimeFormat),
+		StartedAt: FormatTime(startedAt),
 	})
 	if err != nil {
 		return err
@@ -220,7 +335,7 @@ func (c *Client) StartTracking(
 
 	req, err := http.NewRequest(
 		http.MethodPost,
-		apiURL(fmt.Sprintf("/tracking/%s/start", activityID)),
+		c.apiURL(fmt.Sprintf("/tracking/%s/start", activityID)),
 		bytes.NewReader(reqBody),
 	)
 	if err != nil {
@@ -229,7 +344,7 @@ func (c *Client) StartTracking(
 	c.authorize(req, accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	res, err := c.http.Do(req)
+	res, err := c.do("start_tracking", req)
 	if err != nil {
 		return err
 	}
@@ -250,7 +365,7 @@ func (c *Client) StopTracking(
 	stoppedAt time.Time,
 ) error {
 	reqBody, err := json.Marshal(&stopTrackingRequest{
-		StoppedAt: stoppedAt.UTC().Format(TimeFormat),
+		StoppedAt: FormatTime(stoppedAt),
 	})
 	if err != nil {
 		return err
@@ -258,7 +373,7 @@ func (c *Client) StopTracking(
 
 	req, err := http.NewRequest(
 		http.MethodPost,
-		apiURL(fmt.Sprintf("/tracking/%s/stop", activityID)),
+		c.apiURL(fmt.Sprintf("/tracking/%s/stop", activityID)),
 		bytes.NewReader(reqBody),
 	)
 	if err != nil {
@@ -267,7 +382,174 @@ func (c *Client) StopTracking(
 	c.authorize(req, accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	res, err := c.http.Do(req)
+	res, err := c.do("stop_tracking", req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return nil
+}
+
+type createTimeEntryRequest struct {
+	ActivityID string `json:"activityId"`
+	StartedAt  string `json:"startedAt"`
+	StoppedAt  string `json:"stoppedAt"`
+}
+
+// CreateTimeEntry records a time entry of an activity
+func (c *Client) CreateTimeEntry(
+	ctx context.Context,
+	accessToken string,
+	activityID string,
+	startedAt, stoppedAt time.Time,
+) error {
+	reqBody, err := json.Marshal(&createTimeEntryRequest{
+		ActivityID: activityID,
+		StartedAt:  FormatTime(startedAt),
+		StoppedAt:  FormatTime(stoppedAt),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		c.apiURL("/time-entries"),
+		bytes.NewReader(reqBody),
+	)
+	if err != nil {
+		return err
+	}
+	c.authorize(req, accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.do("create_time_entry", req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return nil
+}
+
+// TimeEntry is the time tracked on an activity.
+type TimeEntry struct {
+	ID       string            `json:"id"`
+	Activity Activity          `json:"activity"`
+	Duration TimeEntryDuration `json:"duration"`
+}
+
+// TimeEntryDuration is the interval of a time entry.
+type TimeEntryDuration struct {
+	StartedAt string `json:"startedAt"`
+	StoppedAt string `json:"stoppedAt"`
+}
+
+// StartTime returns the time at which the entry started.
+func (d *TimeEntryDuration) StartTime() (time.Time, error) {
+	return ParseTime(d.StartedAt)
+}
+
+// StopTime returns the time at which the entry stopped.
+func (d *TimeEntryDuration) StopTime() (time.Time, error) {
+	return ParseTime(d.StoppedAt)
+}
+
+type timeEntriesResponse struct {
+	TimeEntries []TimeEntry `json:"timeEntries"`
+}
+
+// TimeEntries returns the time entries which stopped after from and started
+// before to.
+func (c *Client) TimeEntries(
+	ctx context.Context,
+	accessToken string,
+	from, to time.Time,
+) ([]TimeEntry, error) {
+	var apiResponse timeEntriesResponse
+
+	req, err := http.NewRequest(
+		http.MethodGet,
+		c.apiURL(fmt.Sprintf("/time-entries/%s/%s", FormatTime(from), FormatTime(to))),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req, accessToken)
+
+	res, err := c.do("time_entries", req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	err = json.NewDecoder(res.Body).Decode(&apiResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResponse.TimeEntries, nil
+}
+
+type createActivityRequest struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Integration string `json:"integration"`
+}
+
+// CreateActivity registers a new activity, it is not assigned to any side.
+func (c *Client) CreateActivity(
+	ctx context.Context,
+	accessToken string,
+	name, color string,
+) (*Activity, error) {
+	reqBody, err := json.Marshal(&createActivityRequest{
+		Name:        name,
+		Color:       color,
+		Integration: "zei",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.apiURL("/activities"), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req, accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.do("create_activity", req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var activity Activity
+	err = json.NewDecoder(res.Body).Decode(&activity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &activity, nil
+}
+
+// UnassignActivity removes an activity from a device side.
+func (c *Client) UnassignActivity(
+	ctx context.Context,
+	accessToken string,
+	activityID string,
+	deviceSide int,
+) error {
+	req, err := http.NewRequest(http.MethodDelete, c.apiURL(fmt.Sprintf("/activities/%s/device-side/%d", activityID, deviceSide)), nil)
+	if err != nil {
+		return err
+	}
+	c.authorize(req, accessToken)
+
+	res, err := c.do("unassign_activity", req)
 	if err != nil {
 		return err
 	}
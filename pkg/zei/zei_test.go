@@ -0,0 +1,95 @@
+package zei
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeDST(t *testing.T) {
+	cases := []struct {
+		name string
+		zone string
+		wall time.Time
+		want string
+	}{
+		{"before spring forward", "Europe/Paris", time.Date(2019, time.March, 31, 1, 59, 0, 0, time.UTC), "2019-03-31T00:59:00.000"},
+		{"after spring forward", "Europe/Paris", time.Date(2019, time.March, 31, 3, 0, 0, 0, time.UTC), "2019-03-31T01:00:00.000"},
+		{"before fall back", "Europe/Paris", time.Date(2019, time.October, 27, 1, 59, 59, 999e6, time.UTC), "2019-10-26T23:59:59.999"},
+		{"after fall back", "Europe/Paris", time.Date(2019, time.October, 27, 3, 0, 0, 0, time.UTC), "2019-10-27T02:00:00.000"},
+		{"spring forward west of UTC", "America/New_York", time.Date(2019, time.March, 10, 3, 30, 0, 0, time.UTC), "2019-03-10T07:30:00.000"},
+		{"fall back west of UTC", "America/New_York", time.Date(2019, time.November, 3, 0, 30, 0, 0, time.UTC), "2019-11-03T04:30:00.000"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			location, err := time.LoadLocation(c.zone)
+			if err != nil {
+				t.Skipf("time zone %s is not available: %v", c.zone, err)
+			}
+
+			// the wall clock of the case, in the time zone.
+			y, mo, d := c.wall.Date()
+			local := time.Date(y, mo, d, c.wall.Hour(), c.wall.Minute(), c.wall.Second(), c.wall.Nanosecond(), location)
+
+			if got := FormatTime(local); got != c.want {
+				t.Errorf("got %q, want %q", got, c.want)
+			}
+
+			parsed, err := ParseTime(c.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !parsed.Equal(local) || parsed.Location() != time.UTC {
+				t.Errorf("got %s, want %s in UTC", parsed, local)
+			}
+		})
+	}
+}
+
+func TestClientObserver(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/developer/sign-in") {
+			w.Write([]byte(`{"token": "token"}`))
+			return
+		}
+
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer api.Close()
+
+	type request struct {
+		operation string
+		err       error
+	}
+
+	var requests []request
+
+	c := NewClientWithBaseURL(api.URL)
+	c.SetObserver(func(operation string, duration time.Duration, err error) {
+		requests = append(requests, request{operation, err})
+	})
+
+	token, err := c.DeveloperSignIn(context.Background(), "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Activities(context.Background(), token)
+	if err == nil {
+		t.Fatal("got activities, want an error")
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests observed, want 2", len(requests))
+	}
+	if requests[0].operation != "sign_in" || requests[0].err != nil {
+		t.Errorf("got %s observed with %v, want sign_in without error", requests[0].operation, requests[0].err)
+	}
+	if requests[1].operation != "activities" || ErrorClass(requests[1].err) != "server" {
+		t.Errorf("got %s observed with %v, want activities with a server error", requests[1].operation, requests[1].err)
+	}
+}
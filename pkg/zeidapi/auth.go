@@ -0,0 +1,29 @@
+package zeidapi
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/twitchtv/twirp"
+)
+
+// RequireToken serves the requests carrying token as bearer token with h,
+// the others are rejected with an unauthenticated twirp error.
+func RequireToken(token string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") {
+			zeid.WriteError(w, twirp.NewError(twirp.Unauthenticated, "an API token is required"))
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), []byte(token)) != 1 {
+			zeid.WriteError(w, twirp.NewError(twirp.Unauthenticated, "invalid API token"))
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
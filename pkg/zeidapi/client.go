@@ -0,0 +1,107 @@
+package zeidapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// unixScheme prefixes the addresses of the API served on a Unix socket.
+const unixScheme = "unix://"
+
+// DefaultAddress returns the address of the API served by zeid on its
+// default socket.
+func DefaultAddress() string {
+	return unixScheme + DefaultSocketPath()
+}
+
+// ClientOptions configures the connections of a client to zeid.
+type ClientOptions struct {
+	// Token is sent as bearer token when it is not empty.
+	Token string
+
+	// CAFile is the PEM file of the CA verifying the certificate of an
+	// https address, the system CAs are used when it is empty.
+	CAFile string
+
+	// CertFile and KeyFile are the PEM files of the certificate presented
+	// to an https address which verifies its clients.
+	CertFile string
+	KeyFile  string
+}
+
+// NewHTTPClient returns the base URL of the API of zeid at addr and an HTTP
+// client connecting to it. The address is either an HTTP(S) URL or the path
+// of a Unix socket prefixed with unix://.
+func NewHTTPClient(addr string, opts ClientOptions) (string, *http.Client, error) {
+	var transport http.RoundTripper = http.DefaultTransport
+
+	tlsConfig, err := opts.tlsConfig()
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch {
+	case strings.HasPrefix(addr, unixScheme):
+		path := strings.TrimPrefix(addr, unixScheme)
+
+		transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				// a socket in a directory of another user may be served by
+				// them.
+				err := CheckSocketDir(filepath.Dir(path))
+				if err != nil {
+					return nil, err
+				}
+
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		}
+
+		// the host is ignored by the dialer.
+		addr = "http://zeid"
+	case tlsConfig != nil:
+		// the settings of http.DefaultTransport, with the TLS configuration.
+		transport = &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+			TLSClientConfig:       tlsConfig,
+		}
+	}
+
+	if opts.Token != "" {
+		transport = &tokenTransport{token: opts.Token, base: transport}
+	}
+
+	return addr, &http.Client{Transport: transport}, nil
+}
+
+// tokenTransport sets the bearer token of the requests.
+type tokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a RoundTripper must not modify the request, its headers are copied.
+	r := *req
+	r.Header = make(http.Header, len(req.Header)+1)
+	for key, values := range req.Header {
+		r.Header[key] = values
+	}
+	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.token))
+
+	return t.base.RoundTrip(&r)
+}
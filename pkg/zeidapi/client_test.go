@@ -0,0 +1,87 @@
+package zeidapi
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestTokenTransport(t *testing.T) {
+	var authorization string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	base, client, err := NewHTTPClient(server.URL, ClientOptions{Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, base, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if authorization != "Bearer secret" {
+		t.Errorf("got authorization %q, want the bearer token", authorization)
+	}
+
+	// the request of the caller is not modified.
+	if req.Header.Get("Authorization") != "" || req.Header.Get("Accept") != "application/json" {
+		t.Errorf("got headers %v, want the headers of the caller", req.Header)
+	}
+}
+
+func TestNewHTTPClientTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	ca, err := ioutil.TempFile("", "zei-ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(ca.Name())
+
+	err = pem.Encode(ca, &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err == nil {
+		err = ca.Close()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base, client, err := NewHTTPClient(server.URL, ClientOptions{CAFile: ca.Name()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Get(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	// the system CAs don't verify the certificate of the server.
+	_, client, err = NewHTTPClient(server.URL, ClientOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err = client.Get(base)
+	if err == nil {
+		res.Body.Close()
+		t.Error("verified the certificate of the server without its CA")
+	}
+}
@@ -0,0 +1,17 @@
+package zeidapi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultSocketPath returns the path of the Unix socket of the API in the
+// runtime directory of the user.
+func DefaultSocketPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "zei", "zeid.sock")
+	}
+
+	return filepath.Join(os.TempDir(), fmt.Sprintf("zei-%d", os.Getuid()), "zeid.sock")
+}
@@ -0,0 +1,36 @@
+// +build !windows
+
+package zeidapi
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// CheckSocketDir returns an error unless the directory of a socket is owned
+// by the user and only accessible by them, so nobody else can replace the
+// socket. The directory must not be a symbolic link.
+func CheckSocketDir(dir string) error {
+	fi, err := os.Lstat(dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to check API socket directory")
+	}
+
+	switch {
+	case fi.Mode()&os.ModeSymlink != 0:
+		return errors.Errorf("API socket directory %s is a symbolic link", dir)
+	case !fi.IsDir():
+		return errors.Errorf("API socket directory %s is not a directory", dir)
+	case fi.Mode().Perm() != 0700:
+		return errors.Errorf("API socket directory %s has mode %s, expected 0700", dir, fi.Mode().Perm())
+	}
+
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok || int(st.Uid) != os.Getuid() {
+		return errors.Errorf("API socket directory %s is not owned by the user", dir)
+	}
+
+	return nil
+}
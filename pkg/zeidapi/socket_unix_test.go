@@ -0,0 +1,82 @@
+// +build !windows
+
+package zeidapi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckSocketDir(t *testing.T) {
+	root, err := ioutil.TempDir("", "zei-socket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	private, shared, link := filepath.Join(root, "private"), filepath.Join(root, "shared"), filepath.Join(root, "link")
+
+	err = os.Mkdir(private, 0700)
+	if err == nil {
+		err = os.Mkdir(shared, 0755)
+	}
+	if err == nil {
+		err = os.Chmod(shared, 0755)
+	}
+	if err == nil {
+		err = os.Symlink(private, link)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		dir string
+		ok  bool
+	}{
+		{dir: private, ok: true},
+		{dir: shared},
+		{dir: link},
+		{dir: filepath.Join(root, "missing")},
+	}
+
+	for _, c := range cases {
+		err := CheckSocketDir(c.dir)
+		if c.ok && err != nil {
+			t.Errorf("%s: %v", c.dir, err)
+		}
+		if !c.ok && err == nil {
+			t.Errorf("%s: got no error, want one", c.dir)
+		}
+	}
+}
+
+func TestNewHTTPClientSocketDir(t *testing.T) {
+	shared, err := ioutil.TempDir("", "zei-socket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(shared)
+
+	err = os.Chmod(shared, 0777)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base, client, err := NewHTTPClient(unixScheme+filepath.Join(shared, "zeid.sock"), ClientOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Get(base)
+	if err == nil {
+		res.Body.Close()
+		t.Fatal("connected to a socket in a shared directory")
+	}
+	if !strings.Contains(err.Error(), "mode") {
+		t.Errorf("got error %v, want the mode of the directory to be refused", err)
+	}
+}
@@ -0,0 +1,11 @@
+package zeidapi
+
+import (
+	"github.com/pkg/errors"
+)
+
+// CheckSocketDir returns an error, the permissions of socket directories are
+// not checked on Windows so the API isn't served on sockets there.
+func CheckSocketDir(dir string) error {
+	return errors.New("the API socket is not supported on Windows")
+}
@@ -0,0 +1,73 @@
+// Package zeidapi secures the API of zeid and the connections of its
+// clients.
+package zeidapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pauldub/zei/pkg/userdir"
+	"github.com/pkg/errors"
+)
+
+// DefaultTokenPath returns the path of the file holding the API token, in
+// the configuration directory of the user.
+func DefaultTokenPath() string {
+	dir, err := userdir.Config()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "token")
+}
+
+// ReadToken returns the API token held by the file at path, an empty token
+// when the file does not exist.
+func ReadToken(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read API token")
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
+// EnsureToken returns the API token held by the file at path, the file is
+// created with a random token, only readable by the user, when it does not
+// exist.
+func EnsureToken(path string) (string, error) {
+	token, err := ReadToken(path)
+	if err != nil || token != "" {
+		return token, err
+	}
+
+	b := make([]byte, 32)
+	_, err = rand.Read(b)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate API token")
+	}
+	token = hex.EncodeToString(b)
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create API token directory")
+	}
+
+	err = ioutil.WriteFile(path, []byte(token+"\n"), 0600)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to write API token")
+	}
+
+	return token, nil
+}
@@ -0,0 +1,57 @@
+package zeidsvc
+
+import (
+	"strings"
+
+	"github.com/pauldub/zei/pkg/zei"
+)
+
+// findActivity returns the activity with the given ID, or else the one
+// matching the given name. Names match exactly, ignoring case, or else by
+// prefix or substring as long as a single activity matches.
+func findActivity(activities []zei.Activity, id, name string) (*zei.Activity, error) {
+	if id != "" {
+		for i := range activities {
+			if activities[i].ID == id {
+				return &activities[i], nil
+			}
+		}
+
+		return nil, notFoundError("unknown activity %q", id)
+	}
+
+	if name == "" {
+		return nil, invalidArgumentError("activity", "an activity ID or name is required")
+	}
+
+	name = strings.ToLower(name)
+
+	for _, match := range []func(string) bool{
+		func(s string) bool { return s == name },
+		func(s string) bool { return strings.HasPrefix(s, name) },
+		func(s string) bool { return strings.Contains(s, name) },
+	} {
+		var matches []*zei.Activity
+		for i := range activities {
+			if match(strings.ToLower(activities[i].Name)) {
+				matches = append(matches, &activities[i])
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0], nil
+		default:
+			names := make([]string, 0, len(matches))
+			for _, a := range matches {
+				names = append(names, a.Name)
+			}
+
+			return nil, invalidArgumentError("activity", "activity name %q is ambiguous: %s", name, strings.Join(names, ", "))
+		}
+	}
+
+	return nil, notFoundError("no activity matches %q", name)
+}
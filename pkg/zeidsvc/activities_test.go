@@ -0,0 +1,61 @@
+package zeidsvc
+
+import (
+	"testing"
+
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/twitchtv/twirp"
+)
+
+func TestFindActivity(t *testing.T) {
+	activities := []zei.Activity{
+		{ID: "1", Name: "Code"},
+		{ID: "2", Name: "Code review"},
+		{ID: "3", Name: "Meetings"},
+		{ID: "4", Name: "Team meeting"},
+		{ID: "5", Name: "Support"},
+	}
+
+	cases := []struct {
+		name   string
+		id     string
+		search string
+		want   string
+		code   twirp.ErrorCode
+	}{
+		{name: "by ID", id: "3", want: "3"},
+		{name: "unknown ID", id: "6", code: twirp.NotFound},
+		{name: "ID before name", id: "5", search: "Code", want: "5"},
+		{name: "exact name", search: "Meetings", want: "3"},
+		{name: "exact name ignoring case", search: "support", want: "5"},
+		{name: "exact name before prefix", search: "code", want: "1"},
+		{name: "prefix", search: "code r", want: "2"},
+		{name: "prefix before substring", search: "meet", want: "3"},
+		{name: "substring", search: "port", want: "5"},
+		{name: "ambiguous prefix", search: "co", code: twirp.InvalidArgument},
+		{name: "ambiguous substring", search: "e", code: twirp.InvalidArgument},
+		{name: "no match", search: "lunch", code: twirp.NotFound},
+		{name: "no ID nor name", code: twirp.InvalidArgument},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a, err := findActivity(activities, c.id, c.search)
+
+			if c.code != twirp.NoError {
+				twerr, ok := err.(twirp.Error)
+				if !ok || twerr.Code() != c.code {
+					t.Fatalf("got activity %v and error %v, want a %s error", a, err, c.code)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a.ID != c.want {
+				t.Errorf("got activity %s (%s), want %s", a.ID, a.Name, c.want)
+			}
+		})
+	}
+}
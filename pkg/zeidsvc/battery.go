@@ -0,0 +1,115 @@
+package zeidsvc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+)
+
+// batteryHysteresis is how far above the threshold the battery level must
+// rise before a low battery is reported again.
+const batteryHysteresis = 5
+
+// BatteryOptions configures how the battery level of the device is watched.
+type BatteryOptions struct {
+	// Threshold is the battery level, in percents, at or below which the
+	// battery is low.
+	Threshold int
+
+	// Interval between two reads of the battery level, defaults to 15
+	// minutes.
+	Interval time.Duration
+
+	// Clock is the source of time, the system clock is used when nil.
+	Clock clock.Clock
+
+	// OnLow is called with the battery level once it is low, it is called
+	// again only after the battery was charged.
+	OnLow func(level int)
+
+	// OnError is called when reading the battery level failed.
+	OnError func(err error)
+}
+
+// BatteryMonitor periodically reads the battery level of the device of a
+// service.
+type BatteryMonitor struct {
+	svc   ZeiSvc
+	opts  BatteryOptions
+	clock clock.Clock
+
+	mu    sync.Mutex
+	timer clock.Timer
+	low   bool
+}
+
+// NewBatteryMonitor returns a BatteryMonitor reading the battery level of
+// the device of svc.
+func NewBatteryMonitor(svc ZeiSvc, opts BatteryOptions) *BatteryMonitor {
+	if opts.Interval <= 0 {
+		opts.Interval = 15 * time.Minute
+	}
+
+	return &BatteryMonitor{
+		svc:   svc,
+		opts:  opts,
+		clock: clock.OrReal(opts.Clock),
+	}
+}
+
+// Start reads the battery level now and then at each interval.
+func (b *BatteryMonitor) Start() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.check()
+	b.schedule()
+}
+
+// Stop stops reading the battery level.
+func (b *BatteryMonitor) Stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+}
+
+func (b *BatteryMonitor) schedule() {
+	var timer clock.Timer
+	timer = b.clock.AfterFunc(b.opts.Interval, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if b.timer != timer {
+			return
+		}
+
+		b.check()
+		b.schedule()
+	})
+	b.timer = timer
+}
+
+func (b *BatteryMonitor) check() {
+	level, err := b.svc.BatteryLevel()
+	if err != nil {
+		if b.opts.OnError != nil {
+			b.opts.OnError(err)
+		}
+		return
+	}
+
+	switch {
+	case level <= b.opts.Threshold && !b.low:
+		b.low = true
+		if b.opts.OnLow != nil {
+			b.opts.OnLow(level)
+		}
+	case level > b.opts.Threshold+batteryHysteresis:
+		b.low = false
+	}
+}
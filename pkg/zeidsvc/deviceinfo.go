@@ -0,0 +1,110 @@
+package zeidsvc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/go-ble/ble"
+	"github.com/pkg/errors"
+)
+
+// characteristics of the standard battery and device information services.
+var (
+	batteryLevelCharacteristic     = ble.UUID16(0x2a19)
+	modelNumberCharacteristic      = ble.UUID16(0x2a24)
+	serialNumberCharacteristic     = ble.UUID16(0x2a25)
+	firmwareRevisionCharacteristic = ble.UUID16(0x2a26)
+	hardwareRevisionCharacteristic = ble.UUID16(0x2a27)
+	softwareRevisionCharacteristic = ble.UUID16(0x2a28)
+	manufacturerNameCharacteristic = ble.UUID16(0x2a29)
+)
+
+// DeviceInfo is the information of the device information service, fields
+// the device doesn't provide are empty.
+type DeviceInfo struct {
+	Manufacturer     string
+	Model            string
+	SerialNumber     string
+	HardwareRevision string
+	FirmwareRevision string
+	SoftwareRevision string
+}
+
+// readDeviceInfo reads the device information service, it is read once as
+// it doesn't change while the device is connected.
+func readDeviceInfo(conn ble.Client, profile *ble.Profile) DeviceInfo {
+	read := func(uuid ble.UUID) string {
+		c := profile.FindCharacteristic(ble.NewCharacteristic(uuid))
+		if c == nil {
+			return ""
+		}
+
+		value, err := conn.ReadCharacteristic(c)
+		if err != nil {
+			return ""
+		}
+
+		return strings.TrimRight(string(value), "\x00 ")
+	}
+
+	return DeviceInfo{
+		Manufacturer:     read(manufacturerNameCharacteristic),
+		Model:            read(modelNumberCharacteristic),
+		SerialNumber:     read(serialNumberCharacteristic),
+		HardwareRevision: read(hardwareRevisionCharacteristic),
+		FirmwareRevision: read(firmwareRevisionCharacteristic),
+		SoftwareRevision: read(softwareRevisionCharacteristic),
+	}
+}
+
+// Info returns the information of the device.
+func (z *zeisvc) Info() DeviceInfo {
+	return z.info
+}
+
+// BatteryLevel returns the battery level of the device, in percents.
+func (z *zeisvc) BatteryLevel() (int, error) {
+	if z.battery == nil {
+		return 0, failedPreconditionError("the device has no battery service")
+	}
+	if !z.DeviceConnected() {
+		return 0, ErrDeviceDisconnected
+	}
+
+	value, err := z.bleConn.ReadCharacteristic(z.battery)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read battery level")
+	}
+	if len(value) == 0 {
+		return 0, errors.New("empty battery level")
+	}
+
+	return int(value[0]), nil
+}
+
+// DeviceInfo returns the information and battery level of the device.
+func (z *zeisvc) DeviceInfo(ctx context.Context, req *zeid.DeviceInfoReq) (*zeid.DeviceInfoResp, error) {
+	res := &zeid.DeviceInfoResp{
+		Serial:           z.serial,
+		Connected:        z.DeviceConnected(),
+		Manufacturer:     z.info.Manufacturer,
+		Model:            z.info.Model,
+		SerialNumber:     z.info.SerialNumber,
+		HardwareRevision: z.info.HardwareRevision,
+		FirmwareRevision: z.info.FirmwareRevision,
+		SoftwareRevision: z.info.SoftwareRevision,
+	}
+
+	if z.battery != nil && res.Connected {
+		level, err := z.BatteryLevel()
+		if err != nil {
+			return nil, err
+		}
+
+		res.HasBattery = true
+		res.BatteryLevel = int64(level)
+	}
+
+	return res, nil
+}
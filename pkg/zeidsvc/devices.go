@@ -0,0 +1,304 @@
+package zeidsvc
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/version"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+)
+
+// Devices serves the API for several devices, requests are routed to the
+// service of the device they address by serial number.
+type Devices struct {
+	startedAt time.Time
+
+	mu       sync.RWMutex
+	services map[string]ZeiSvc
+}
+
+// NewDevices returns an empty set of devices, started at the time of clk.
+func NewDevices(clk clock.Clock) *Devices {
+	return &Devices{
+		startedAt: clock.OrReal(clk).Now(),
+		services:  map[string]ZeiSvc{},
+	}
+}
+
+// Add registers the service of a device, replacing the service of a previous
+// connection to the same device.
+func (d *Devices) Add(svc ZeiSvc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.services[svc.Serial()] = svc
+}
+
+// Remove unregisters the service of a device, unless it was already replaced
+// by the service of a new connection.
+func (d *Devices) Remove(svc ZeiSvc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.services[svc.Serial()] == svc {
+		delete(d.services, svc.Serial())
+	}
+}
+
+// Get returns the service of the device with the given serial number, or of
+// the only connected device when serial is empty.
+func (d *Devices) Get(serial string) (ZeiSvc, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if serial != "" {
+		svc, ok := d.services[serial]
+		if !ok {
+			return nil, notFoundError("unknown device %q", serial)
+		}
+		return svc, nil
+	}
+
+	var connected []ZeiSvc
+	for _, svc := range d.services {
+		if svc.DeviceConnected() {
+			connected = append(connected, svc)
+		}
+	}
+
+	switch len(connected) {
+	case 0:
+		return nil, ErrDeviceDisconnected
+	case 1:
+		return connected[0], nil
+	}
+
+	return nil, invalidArgumentError("device", "several devices are connected, choose one of: %s", strings.Join(serials(connected), ", "))
+}
+
+// All returns the services of the devices, ordered by serial number.
+func (d *Devices) All() []ZeiSvc {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	services := make([]ZeiSvc, 0, len(d.services))
+	for _, svc := range d.services {
+		services = append(services, svc)
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Serial() < services[j].Serial()
+	})
+
+	return services
+}
+
+func serials(services []ZeiSvc) []string {
+	serials := make([]string, 0, len(services))
+	for _, svc := range services {
+		serials = append(serials, svc.Serial())
+	}
+	sort.Strings(serials)
+
+	return serials
+}
+
+// ListDevices returns the devices, along with the activity tracked on each.
+func (d *Devices) ListDevices(ctx context.Context, req *zeid.ListDevicesReq) (*zeid.ListDevicesResp, error) {
+	res := &zeid.ListDevicesResp{}
+
+	for _, svc := range d.All() {
+		devices, err := svc.ListDevices(ctx, req)
+		if err != nil {
+			return nil, twirpError(err)
+		}
+
+		res.Devices = append(res.Devices, devices.Devices...)
+	}
+
+	return res, nil
+}
+
+// Health returns the version of zeid and the health of each device.
+func (d *Devices) Health(ctx context.Context, req *zeid.HealthReq) (*zeid.HealthResp, error) {
+	startedAt, err := ptypes.TimestampProto(d.startedAt)
+	if err != nil {
+		return nil, twirpError(errors.Wrap(err, "failed to convert start time"))
+	}
+
+	res := &zeid.HealthResp{
+		Version:   version.VERSION,
+		StartedAt: startedAt,
+	}
+
+	for _, svc := range d.All() {
+		health, err := svc.Health(ctx, req)
+		if err != nil {
+			return nil, twirpError(err)
+		}
+
+		res.Devices = append(res.Devices, health.Devices...)
+	}
+
+	return res, nil
+}
+
+func (d *Devices) ListActivities(ctx context.Context, req *zeid.ListActivitiesReq) (*zeid.ListActivitiesResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.ListActivities(ctx, req)
+
+	return res, twirpError(err)
+}
+
+func (d *Devices) CurrentActivity(ctx context.Context, req *zeid.CurrentActivityReq) (*zeid.CurrentActivityResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.CurrentActivity(ctx, req)
+
+	return res, twirpError(err)
+}
+
+func (d *Devices) AssignActivity(ctx context.Context, req *zeid.AssignActivityReq) (*zeid.AssignActivityResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.AssignActivity(ctx, req)
+
+	return res, twirpError(err)
+}
+
+func (d *Devices) ListSides(ctx context.Context, req *zeid.ListSidesReq) (*zeid.ListSidesResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.ListSides(ctx, req)
+
+	return res, twirpError(err)
+}
+
+func (d *Devices) ApplyLayout(ctx context.Context, req *zeid.ApplyLayoutReq) (*zeid.ApplyLayoutResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.ApplyLayout(ctx, req)
+
+	return res, twirpError(err)
+}
+
+func (d *Devices) StartActivity(ctx context.Context, req *zeid.StartActivityReq) (*zeid.StartActivityResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.StartActivity(ctx, req)
+
+	return res, twirpError(err)
+}
+
+func (d *Devices) StopActivity(ctx context.Context, req *zeid.StopActivityReq) (*zeid.StopActivityResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.StopActivity(ctx, req)
+
+	return res, twirpError(err)
+}
+
+func (d *Devices) History(ctx context.Context, req *zeid.HistoryReq) (*zeid.HistoryResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.History(ctx, req)
+
+	return res, twirpError(err)
+}
+
+func (d *Devices) ResolveIdleGap(ctx context.Context, req *zeid.ResolveIdleGapReq) (*zeid.ResolveIdleGapResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.ResolveIdleGap(ctx, req)
+
+	return res, twirpError(err)
+}
+
+func (d *Devices) GetReminderRules(ctx context.Context, req *zeid.GetReminderRulesReq) (*zeid.GetReminderRulesResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.GetReminderRules(ctx, req)
+
+	return res, twirpError(err)
+}
+
+func (d *Devices) UpdateReminderRules(ctx context.Context, req *zeid.UpdateReminderRulesReq) (*zeid.UpdateReminderRulesResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.UpdateReminderRules(ctx, req)
+
+	return res, twirpError(err)
+}
+
+func (d *Devices) ListProfiles(ctx context.Context, req *zeid.ListProfilesReq) (*zeid.ListProfilesResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.ListProfiles(ctx, req)
+
+	return res, twirpError(err)
+}
+
+func (d *Devices) SwitchProfile(ctx context.Context, req *zeid.SwitchProfileReq) (*zeid.SwitchProfileResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.SwitchProfile(ctx, req)
+
+	return res, twirpError(err)
+}
+
+func (d *Devices) DeviceInfo(ctx context.Context, req *zeid.DeviceInfoReq) (*zeid.DeviceInfoResp, error) {
+	svc, err := d.Get(req.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.DeviceInfo(ctx, req)
+
+	return res, twirpError(err)
+}
@@ -0,0 +1,103 @@
+package zeidsvc
+
+import (
+	"testing"
+
+	"github.com/twitchtv/twirp"
+)
+
+// serialSvc is the service of a device known by its serial number and
+// whether it is connected only.
+type serialSvc struct {
+	ZeiSvc
+
+	serial       string
+	disconnected bool
+}
+
+func (s *serialSvc) Serial() string {
+	return s.serial
+}
+
+func (s *serialSvc) DeviceConnected() bool {
+	return !s.disconnected
+}
+
+func TestDevicesGet(t *testing.T) {
+	var (
+		z1             = &serialSvc{serial: "Z1"}
+		z2             = &serialSvc{serial: "Z2"}
+		z1Disconnected = &serialSvc{serial: "Z1", disconnected: true}
+	)
+
+	cases := []struct {
+		name     string
+		services []*serialSvc
+		serial   string
+		want     *serialSvc
+		code     twirp.ErrorCode
+	}{
+		{name: "single device", services: []*serialSvc{z1}, want: z1},
+		{name: "single disconnected device", services: []*serialSvc{z1Disconnected}, code: twirp.Unavailable},
+		{name: "single connected device", services: []*serialSvc{z1Disconnected, z2}, want: z2},
+		{name: "several connected devices", services: []*serialSvc{z1, z2}, code: twirp.InvalidArgument},
+		{name: "no device", code: twirp.Unavailable},
+		{name: "by serial", services: []*serialSvc{z1, z2}, serial: "Z2", want: z2},
+		{name: "disconnected by serial", services: []*serialSvc{z1Disconnected}, serial: "Z1", want: z1Disconnected},
+		{name: "unknown serial", services: []*serialSvc{z1}, serial: "Z2", code: twirp.NotFound},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			devices := NewDevices(nil)
+			for _, svc := range c.services {
+				devices.Add(svc)
+			}
+
+			svc, err := devices.Get(c.serial)
+
+			if c.code != twirp.NoError {
+				twerr, ok := err.(twirp.Error)
+				if !ok || twerr.Code() != c.code {
+					t.Fatalf("got service %v and error %v, want a %s error", svc, err, c.code)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if svc != c.want {
+				t.Errorf("got service %p, want %p", svc, c.want)
+			}
+		})
+	}
+}
+
+func TestDevicesRemove(t *testing.T) {
+	devices := NewDevices(nil)
+
+	previous, current := &serialSvc{serial: "Z1"}, &serialSvc{serial: "Z1"}
+	devices.Add(previous)
+	devices.Add(current)
+
+	// the previous connection doesn't remove the service of the current one.
+	devices.Remove(previous)
+
+	svc, err := devices.Get("Z1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svc != current {
+		t.Errorf("got service %p, want %p", svc, current)
+	}
+
+	devices.Remove(current)
+
+	if _, err := devices.Get("Z1"); err == nil {
+		t.Error("removed device is still served")
+	}
+	if all := devices.All(); len(all) != 0 {
+		t.Errorf("got %d devices, want none", len(all))
+	}
+}
@@ -0,0 +1,75 @@
+package zeidsvc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pkg/errors"
+	"github.com/twitchtv/twirp"
+)
+
+// ErrDeviceDisconnected is returned when a request needs the device while
+// it is disconnected.
+var ErrDeviceDisconnected = twirp.NewError(twirp.Unavailable, "the device is disconnected")
+
+func notFoundError(format string, args ...interface{}) error {
+	return twirp.NotFoundError(fmt.Sprintf(format, args...))
+}
+
+// invalidArgumentError returns an invalid argument error with the given
+// message, unlike twirp.InvalidArgumentError which prefixes it with the name
+// of the argument.
+func invalidArgumentError(argument, format string, args ...interface{}) error {
+	return twirp.NewError(twirp.InvalidArgument, fmt.Sprintf(format, args...)).WithMeta("argument", argument)
+}
+
+func failedPreconditionError(format string, args ...interface{}) error {
+	return twirp.NewError(twirp.FailedPrecondition, fmt.Sprintf(format, args...))
+}
+
+// twirpError maps err to the twirp error code of its cause, so clients can
+// tell a missing activity or a disconnected device from a failure of zeid.
+// The message keeps the context err was wrapped with.
+func twirpError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	cause := errors.Cause(err)
+	msg := err.Error()
+
+	switch cause {
+	case context.Canceled:
+		return twirp.NewError(twirp.Canceled, msg)
+	case context.DeadlineExceeded:
+		return twirp.NewError(twirp.DeadlineExceeded, msg)
+	}
+
+	switch c := cause.(type) {
+	case twirp.Error:
+		twerr := twirp.NewError(c.Code(), strings.TrimSuffix(msg, c.Error())+c.Msg())
+		for key, value := range c.MetaMap() {
+			twerr = twerr.WithMeta(key, value)
+		}
+		return twerr
+	case *zei.StatusError:
+		code := twirp.Internal
+		switch {
+		case zei.IsUnauthorized(c):
+			code = twirp.Unauthenticated
+		case c.StatusCode == http.StatusNotFound:
+			code = twirp.NotFound
+		case c.StatusCode >= http.StatusInternalServerError:
+			code = twirp.Unavailable
+		}
+		return twirp.NewError(code, msg).WithMeta("cause", "api")
+	case net.Error:
+		return twirp.NewError(twirp.Unavailable, msg).WithMeta("cause", "api")
+	}
+
+	return twirp.InternalErrorWith(err)
+}
@@ -0,0 +1,140 @@
+package zeidsvc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pkg/errors"
+	"github.com/twitchtv/twirp"
+)
+
+// timeoutError is a net.Error, as returned by the HTTP client of the API.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestTwirpError(t *testing.T) {
+	cases := []struct {
+		name  string
+		err   error
+		code  twirp.ErrorCode
+		msg   string
+		cause string
+		meta  map[string]string
+	}{
+		{
+			name:  "unauthorized",
+			err:   errors.Wrap(&zei.StatusError{StatusCode: http.StatusUnauthorized}, "failed to list activities"),
+			code:  twirp.Unauthenticated,
+			msg:   "failed to list activities: ZEI API answered with status 401",
+			cause: "api",
+		},
+		{
+			name:  "forbidden",
+			err:   &zei.StatusError{StatusCode: http.StatusForbidden, Message: "expired"},
+			code:  twirp.Unauthenticated,
+			msg:   "ZEI API answered with status 403: expired",
+			cause: "api",
+		},
+		{
+			name:  "not found",
+			err:   errors.Wrap(&zei.StatusError{StatusCode: http.StatusNotFound}, "failed to stop tracking"),
+			code:  twirp.NotFound,
+			msg:   "failed to stop tracking: ZEI API answered with status 404",
+			cause: "api",
+		},
+		{
+			name:  "server error",
+			err:   &zei.StatusError{StatusCode: http.StatusBadGateway},
+			code:  twirp.Unavailable,
+			msg:   "ZEI API answered with status 502",
+			cause: "api",
+		},
+		{
+			name:  "client error",
+			err:   &zei.StatusError{StatusCode: http.StatusBadRequest},
+			code:  twirp.Internal,
+			msg:   "ZEI API answered with status 400",
+			cause: "api",
+		},
+		{
+			name:  "timeout",
+			err:   errors.Wrap(timeoutError{}, "failed to sign in"),
+			code:  twirp.Unavailable,
+			msg:   "failed to sign in: i/o timeout",
+			cause: "api",
+		},
+		{
+			name: "canceled",
+			err:  errors.Wrap(context.Canceled, "failed to start tracking"),
+			code: twirp.Canceled,
+			msg:  "failed to start tracking: context canceled",
+		},
+		{
+			name: "deadline exceeded",
+			err:  context.DeadlineExceeded,
+			code: twirp.DeadlineExceeded,
+			msg:  "context deadline exceeded",
+		},
+		{
+			name: "wrapped twirp error",
+			err:  errors.Wrap(notFoundError("no activity %q", "Lunch"), "failed to start activity"),
+			code: twirp.NotFound,
+			msg:  `failed to start activity: no activity "Lunch"`,
+		},
+		{
+			name: "wrapped twirp error with meta",
+			err:  errors.Wrap(invalidArgumentError("side", "side %d is out of range", 9), "failed to assign side"),
+			code: twirp.InvalidArgument,
+			msg:  "failed to assign side: side 9 is out of range",
+			meta: map[string]string{"argument": "side"},
+		},
+		{
+			name: "twirp error",
+			err:  ErrDeviceDisconnected,
+			code: twirp.Unavailable,
+			msg:  "the device is disconnected",
+		},
+		{
+			name:  "other error",
+			err:   errors.New("disk full"),
+			code:  twirp.Internal,
+			msg:   "disk full",
+			cause: "*errors.fundamental",
+		},
+	}
+
+	for _, c := range cases {
+		err, ok := twirpError(c.err).(twirp.Error)
+		if !ok {
+			t.Errorf("%s: got %T, want a twirp error", c.name, twirpError(c.err))
+			continue
+		}
+
+		if err.Code() != c.code {
+			t.Errorf("%s: got code %s, want %s", c.name, err.Code(), c.code)
+		}
+		if err.Msg() != c.msg {
+			t.Errorf("%s: got message %q, want %q", c.name, err.Msg(), c.msg)
+		}
+		if err.Meta("cause") != c.cause {
+			t.Errorf("%s: got cause %q, want %q", c.name, err.Meta("cause"), c.cause)
+		}
+		for key, value := range c.meta {
+			if err.Meta(key) != value {
+				t.Errorf("%s: got %s %q, want %q", c.name, key, err.Meta(key), value)
+			}
+		}
+	}
+
+	if err := twirpError(nil); err != nil {
+		t.Errorf("got %v for no error, want nil", err)
+	}
+}
@@ -0,0 +1,262 @@
+package zeidsvc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/zei"
+)
+
+// FlipOptions configures how device orientation changes are turned into
+// activity changes.
+type FlipOptions struct {
+	// SettleDelay is how long the device must rest on a side before the
+	// new side is committed. Transient orientations reported while the
+	// device is bumped or picked up are ignored.
+	SettleDelay time.Duration
+
+	// MinDuration is the minimum duration of a time entry. An activity left
+	// before it reached this duration is merged back into the surrounding
+	// activity instead of being tracked.
+	MinDuration time.Duration
+
+	// Clock is the source of time, the system clock is used when nil.
+	Clock clock.Clock
+
+	// OnChange is called once the activity on top of the device is tracked,
+	// activities merged back before MinDuration elapsed are not reported.
+	OnChange func(from, to zei.Activity)
+
+	// OnError is called when tracking of an activity change failed.
+	OnError func(err error)
+}
+
+// Flipper debounces device orientation changes before switching the tracked
+// activity of a service.
+type Flipper struct {
+	ctx   context.Context
+	svc   ZeiSvc
+	opts  FlipOptions
+	clock clock.Clock
+
+	mu sync.Mutex
+
+	// side reported by the device and waiting to settle.
+	pendingSide  int
+	pendingAt    time.Time
+	pendingTimer clock.Timer
+
+	// activity on top of the device which has not been tracked for
+	// MinDuration yet.
+	tentative      *zei.Activity
+	tentativeAt    time.Time
+	tentativeTimer clock.Timer
+
+	// activities waiting to be tracked, the first one is being tracked. The
+	// API is called without holding mu so flips are not blocked meanwhile.
+	queue []flip
+}
+
+// flip is an activity to track from the given time.
+type flip struct {
+	activity zei.Activity
+	at       time.Time
+}
+
+// NewFlipper returns a Flipper switching activities of svc.
+func NewFlipper(ctx context.Context, svc ZeiSvc, opts FlipOptions) *Flipper {
+	return &Flipper{
+		ctx:         ctx,
+		svc:         svc,
+		opts:        opts,
+		clock:       clock.OrReal(opts.Clock),
+		pendingSide: -1,
+	}
+}
+
+// Flip must be called with each side reported by the device.
+func (f *Flipper) Flip(side int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if side < minSide || side > maxSide {
+		side = 0
+	}
+
+	if f.pendingTimer != nil {
+		if side == f.pendingSide {
+			return
+		}
+
+		f.pendingTimer.Stop()
+		f.pendingTimer = nil
+	}
+
+	at := f.clock.Now()
+
+	if f.opts.SettleDelay <= 0 {
+		f.commit(side, at)
+		return
+	}
+
+	f.pendingSide = side
+	f.pendingAt = at
+
+	var timer clock.Timer
+	timer = f.clock.AfterFunc(f.opts.SettleDelay, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if f.pendingTimer != timer {
+			return
+		}
+		f.pendingTimer = nil
+
+		f.commit(f.pendingSide, f.pendingAt)
+	})
+	f.pendingTimer = timer
+}
+
+// Stop cancels pending side changes.
+func (f *Flipper) Stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.pendingTimer != nil {
+		f.pendingTimer.Stop()
+		f.pendingTimer = nil
+	}
+
+	f.cancelTentative()
+}
+
+// Top returns the activity on top of the device, which may not be tracked
+// yet.
+func (f *Flipper) Top() zei.Activity {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.top()
+}
+
+// QueueDepth returns the number of activities waiting to be tracked.
+func (f *Flipper) QueueDepth() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.queue)
+}
+
+func (f *Flipper) top() zei.Activity {
+	if f.tentative != nil {
+		return *f.tentative
+	}
+
+	return f.tracked()
+}
+
+// tracked returns the activity tracked once the queued activities are.
+func (f *Flipper) tracked() zei.Activity {
+	if n := len(f.queue); n > 0 {
+		return f.queue[n-1].activity
+	}
+
+	return f.svc.Current()
+}
+
+// commit makes the activity of side the one on top of the device, the device
+// was flipped on that side at the given time.
+func (f *Flipper) commit(side int, at time.Time) {
+	newActivity, ok := f.svc.GetActivity(side)
+	if !ok {
+		return
+	}
+
+	top := f.top()
+	if newActivity.ID == top.ID {
+		return
+	}
+
+	f.cancelTentative()
+
+	// back to the tracked activity before the minimum duration elapsed, the
+	// short entry is merged into it.
+	if newActivity.ID == f.tracked().ID {
+		return
+	}
+
+	if f.opts.MinDuration <= 0 {
+		f.enqueue(newActivity, at)
+		return
+	}
+
+	f.tentative = &newActivity
+	f.tentativeAt = at
+
+	var timer clock.Timer
+	timer = f.clock.AfterFunc(f.opts.MinDuration-f.clock.Since(at), func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if f.tentativeTimer != timer {
+			return
+		}
+
+		f.enqueue(*f.tentative, f.tentativeAt)
+		f.tentative = nil
+		f.tentativeTimer = nil
+	})
+	f.tentativeTimer = timer
+}
+
+func (f *Flipper) cancelTentative() {
+	if f.tentativeTimer != nil {
+		f.tentativeTimer.Stop()
+	}
+
+	f.tentative = nil
+	f.tentativeTimer = nil
+}
+
+// enqueue schedules tracking of a from the given time, after the activities
+// already queued.
+func (f *Flipper) enqueue(a zei.Activity, at time.Time) {
+	f.queue = append(f.queue, flip{activity: a, at: at})
+	if len(f.queue) == 1 {
+		go f.drain()
+	}
+}
+
+// drain tracks the queued activities in order, until the queue is empty.
+func (f *Flipper) drain() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for len(f.queue) > 0 {
+		next := f.queue[0]
+
+		f.mu.Unlock()
+		f.track(next.activity, next.at)
+		f.mu.Lock()
+
+		f.queue = f.queue[1:]
+	}
+}
+
+func (f *Flipper) track(a zei.Activity, at time.Time) {
+	from := f.svc.Current()
+
+	err := f.svc.Switch(f.ctx, a, at)
+	if err != nil {
+		if f.opts.OnError != nil {
+			f.opts.OnError(err)
+		}
+		return
+	}
+
+	if f.opts.OnChange != nil {
+		f.opts.OnChange(from, a)
+	}
+}
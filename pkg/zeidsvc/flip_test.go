@@ -0,0 +1,250 @@
+package zeidsvc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/zei"
+)
+
+var (
+	coding   = zei.Activity{ID: "1", Name: "Coding", DeviceSide: 1}
+	meetings = zei.Activity{ID: "2", Name: "Meetings", DeviceSide: 2}
+)
+
+// flipSvc is a service tracking the activities it is switched to.
+type flipSvc struct {
+	ZeiSvc
+
+	mu       sync.Mutex
+	current  zei.Activity
+	since    time.Time
+	switches []flip
+	gap      *IdleGap
+	rules    ReminderRules
+	focus    *FocusSession
+
+	// onSwitch is called once switched, without holding mu.
+	onSwitch func(a zei.Activity, at time.Time)
+}
+
+func (s *flipSvc) Current() zei.Activity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current
+}
+
+func (s *flipSvc) StartTime() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.since
+}
+
+func (s *flipSvc) ReminderRules() ReminderRules {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.rules
+}
+
+func (s *flipSvc) SetFocusSession(session *FocusSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.focus = session
+}
+
+func (s *flipSvc) SetIdleGap(gap IdleGap) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.gap = &gap
+}
+
+func (s *flipSvc) GetActivity(side int) (zei.Activity, bool) {
+	switch side {
+	case 0:
+		return idleActivity, true
+	case coding.DeviceSide:
+		return coding, true
+	case meetings.DeviceSide:
+		return meetings, true
+	}
+
+	return zei.Activity{}, false
+}
+
+func (s *flipSvc) Switch(ctx context.Context, a zei.Activity, at time.Time) error {
+	s.mu.Lock()
+	s.current = a
+	s.since = at
+	s.switches = append(s.switches, flip{activity: a, at: at})
+	onSwitch := s.onSwitch
+	s.mu.Unlock()
+
+	if onSwitch != nil {
+		onSwitch(a, at)
+	}
+
+	return nil
+}
+
+// assertSwitches checks the activities tracked and when they started.
+func (s *flipSvc) assertSwitches(t *testing.T, want ...flip) {
+	t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.switches) != len(want) {
+		t.Fatalf("got %d switches %v, want %d %v", len(s.switches), s.switches, len(want), want)
+	}
+
+	for i, got := range s.switches {
+		if got.activity.ID != want[i].activity.ID || !got.at.Equal(want[i].at) {
+			t.Errorf("switch %d: got %s at %s, want %s at %s", i, got.activity.Name, got.at, want[i].activity.Name, want[i].at)
+		}
+	}
+}
+
+type flipTest struct {
+	t       *testing.T
+	clock   *clock.Fake
+	svc     *flipSvc
+	flipper *Flipper
+
+	mu      sync.Mutex
+	changes []zei.Activity
+}
+
+func newFlipTest(t *testing.T, settleDelay, minDuration time.Duration) *flipTest {
+	ft := &flipTest{
+		t:     t,
+		clock: clock.NewFake(time.Date(2019, time.March, 4, 9, 0, 0, 0, time.UTC)),
+		svc:   &flipSvc{current: coding},
+	}
+
+	ft.flipper = NewFlipper(context.Background(), ft.svc, FlipOptions{
+		SettleDelay: settleDelay,
+		MinDuration: minDuration,
+		Clock:       ft.clock,
+		OnChange: func(from, to zei.Activity) {
+			ft.mu.Lock()
+			defer ft.mu.Unlock()
+
+			ft.changes = append(ft.changes, to)
+		},
+		OnError: func(err error) {
+			t.Errorf("unexpected error: %v", err)
+		},
+	})
+
+	return ft
+}
+
+// advance moves the clock forward and waits for the activities it committed
+// to be tracked.
+func (ft *flipTest) advance(d time.Duration) {
+	ft.clock.Advance(d)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if ft.flipper.QueueDepth() == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			ft.t.Fatal("activities still being tracked after 1s")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// assertSwitches checks the activities tracked so far and when they started.
+func (ft *flipTest) assertSwitches(want ...flip) {
+	ft.t.Helper()
+
+	ft.svc.assertSwitches(ft.t, want...)
+
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+
+	if len(ft.changes) != len(want) {
+		ft.t.Errorf("got %d changes, want %d", len(ft.changes), len(want))
+	}
+}
+
+func TestFlipperSettleDelay(t *testing.T) {
+	ft := newFlipTest(t, time.Second, 0)
+	start := ft.clock.Now()
+
+	// bumped on meetings then laid on idle, only the last side is tracked.
+	ft.flipper.Flip(meetings.DeviceSide)
+	ft.advance(500 * time.Millisecond)
+	ft.flipper.Flip(0)
+	ft.advance(500 * time.Millisecond)
+	ft.assertSwitches()
+
+	// reporting the same side again does not delay it.
+	ft.flipper.Flip(0)
+	ft.advance(500 * time.Millisecond)
+	ft.assertSwitches(flip{idleActivity, start.Add(500 * time.Millisecond)})
+
+	if top := ft.flipper.Top(); top.ID != idleActivity.ID {
+		t.Errorf("got %s on top, want %s", top.Name, idleActivity.Name)
+	}
+}
+
+func TestFlipperBounceBack(t *testing.T) {
+	ft := newFlipTest(t, time.Second, 0)
+
+	ft.flipper.Flip(meetings.DeviceSide)
+	ft.advance(900 * time.Millisecond)
+	ft.flipper.Flip(coding.DeviceSide)
+	ft.advance(10 * time.Second)
+
+	ft.assertSwitches()
+}
+
+func TestFlipperMinDurationMerge(t *testing.T) {
+	ft := newFlipTest(t, time.Second, time.Minute)
+
+	ft.flipper.Flip(meetings.DeviceSide)
+	ft.advance(time.Second)
+
+	if top := ft.flipper.Top(); top.ID != meetings.ID {
+		t.Errorf("got %s on top, want %s", top.Name, meetings.Name)
+	}
+
+	ft.advance(30 * time.Second)
+	ft.flipper.Flip(coding.DeviceSide)
+	ft.advance(time.Hour)
+
+	ft.assertSwitches()
+
+	if top := ft.flipper.Top(); top.ID != coding.ID {
+		t.Errorf("got %s on top, want %s", top.Name, coding.Name)
+	}
+}
+
+func TestFlipperMinDurationCommit(t *testing.T) {
+	ft := newFlipTest(t, time.Second, time.Minute)
+	start := ft.clock.Now()
+
+	ft.flipper.Flip(meetings.DeviceSide)
+	ft.advance(59 * time.Second)
+	ft.assertSwitches()
+
+	// the entry starts when the device was flipped.
+	ft.advance(time.Second)
+	ft.assertSwitches(flip{meetings, start})
+
+	ft.flipper.Flip(coding.DeviceSide)
+	ft.advance(2 * time.Minute)
+	ft.assertSwitches(flip{meetings, start}, flip{coding, start.Add(time.Minute)})
+}
@@ -0,0 +1,287 @@
+package zeidsvc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+)
+
+// FocusOptions configures the focus sessions started by flipping the device
+// to a designated side.
+type FocusOptions struct {
+	// Side of the device starting a focus session.
+	Side int
+
+	// Work and Break are the durations of the work and break phases of a
+	// cycle.
+	Work  time.Duration
+	Break time.Duration
+
+	// Cycles is the number of work phases of a session, sessions last until
+	// the device is flipped to another side when zero.
+	Cycles int
+
+	// BreakSide is the side of the activity tracked during breaks, the
+	// focus activity keeps being tracked when zero.
+	BreakSide int
+
+	// Clock is the source of time, the system clock is used when nil.
+	Clock clock.Clock
+
+	// OnEvent is called when a phase of a session starts or when a session
+	// ends.
+	OnEvent func(e FocusEvent)
+
+	// OnError is called when switching to or from the break activity
+	// failed.
+	OnError func(err error)
+}
+
+// FocusPhase is a phase of a focus session cycle.
+type FocusPhase int
+
+const (
+	// FocusWork is the phase during which the focus activity is tracked.
+	FocusWork FocusPhase = iota
+	// FocusBreak is the pause between two work phases.
+	FocusBreak
+)
+
+// FocusSession is the state of a running focus session.
+type FocusSession struct {
+	Activity zei.Activity
+	Phase    FocusPhase
+	// Cycle is the current cycle, starting at 1.
+	Cycle  int
+	Cycles int
+	// EndsAt is the time at which the current phase ends.
+	EndsAt time.Time
+}
+
+// FocusEventKind is the kind of a focus session event.
+type FocusEventKind int
+
+const (
+	// FocusStarted is sent when a work phase starts.
+	FocusStarted FocusEventKind = iota
+	// FocusBreakStarted is sent when a break phase starts.
+	FocusBreakStarted
+	// FocusEnded is sent when the last work phase ended, or when the
+	// session was interrupted by flipping the device.
+	FocusEnded
+)
+
+// FocusEvent is a change of phase of a focus session.
+type FocusEvent struct {
+	Kind    FocusEventKind
+	Session FocusSession
+	At      time.Time
+}
+
+// Focus runs focus sessions of the activity on the designated side of the
+// device.
+type Focus struct {
+	ctx   context.Context
+	svc   ZeiSvc
+	opts  FocusOptions
+	clock clock.Clock
+
+	mu      sync.Mutex
+	timer   clock.Timer
+	session *FocusSession
+	// pending are the activities to switch to once mu is released.
+	pending []focusSwitch
+}
+
+type focusSwitch struct {
+	activity zei.Activity
+	at       time.Time
+}
+
+// NewFocus returns a Focus running sessions on svc.
+func NewFocus(ctx context.Context, svc ZeiSvc, opts FocusOptions) *Focus {
+	return &Focus{
+		ctx:   ctx,
+		svc:   svc,
+		opts:  opts,
+		clock: clock.OrReal(opts.Clock),
+	}
+}
+
+// Changed must be called when the activity on top of the device changed, a
+// session starts when the designated side is on top and ends otherwise.
+func (f *Focus) Changed(from, to zei.Activity) {
+	f.mu.Lock()
+	defer f.unlock()
+
+	now := f.clock.Now()
+
+	if f.session != nil {
+		f.end(now)
+	}
+
+	if to.DeviceSide != f.opts.Side || to.Name == idleActivity.Name {
+		return
+	}
+
+	f.session = &FocusSession{
+		Activity: to,
+		Cycles:   f.opts.Cycles,
+	}
+
+	f.work(now)
+}
+
+// Stop interrupts the running session.
+func (f *Focus) Stop() {
+	f.mu.Lock()
+	defer f.unlock()
+
+	if f.session != nil {
+		f.end(f.clock.Now())
+	}
+}
+
+func (f *Focus) work(at time.Time) {
+	f.session.Phase = FocusWork
+	f.session.Cycle++
+	f.session.EndsAt = at.Add(f.opts.Work)
+
+	f.event(FocusStarted, at)
+	f.schedule(f.opts.Work, func(at time.Time) {
+		if f.session.Cycles > 0 && f.session.Cycle >= f.session.Cycles {
+			f.end(at)
+			return
+		}
+
+		f.pause(at)
+	})
+}
+
+func (f *Focus) pause(at time.Time) {
+	f.session.Phase = FocusBreak
+	f.session.EndsAt = at.Add(f.opts.Break)
+
+	if breakActivity, ok := f.breakActivity(); ok {
+		f.track(breakActivity, at)
+	}
+
+	f.event(FocusBreakStarted, at)
+	f.schedule(f.opts.Break, func(at time.Time) {
+		if _, ok := f.breakActivity(); ok {
+			f.track(f.session.Activity, at)
+		}
+
+		f.work(at)
+	})
+}
+
+func (f *Focus) end(at time.Time) {
+	if f.timer != nil {
+		f.timer.Stop()
+		f.timer = nil
+	}
+
+	f.event(FocusEnded, at)
+	f.session = nil
+	f.svc.SetFocusSession(nil)
+}
+
+// schedule calls next once d elapsed, unless the session changed meanwhile.
+func (f *Focus) schedule(d time.Duration, next func(at time.Time)) {
+	var timer clock.Timer
+	timer = f.clock.AfterFunc(d, func() {
+		f.mu.Lock()
+		defer f.unlock()
+
+		if f.timer != timer {
+			return
+		}
+		f.timer = nil
+
+		next(f.clock.Now())
+	})
+	f.timer = timer
+}
+
+func (f *Focus) breakActivity() (zei.Activity, bool) {
+	if f.opts.BreakSide == 0 || f.opts.BreakSide == f.opts.Side {
+		return zei.Activity{}, false
+	}
+
+	return f.svc.GetActivity(f.opts.BreakSide)
+}
+
+// track switches to a once f.mu is released.
+func (f *Focus) track(a zei.Activity, at time.Time) {
+	f.pending = append(f.pending, focusSwitch{activity: a, at: at})
+}
+
+// unlock releases f.mu, then switches to the activities tracked meanwhile.
+func (f *Focus) unlock() {
+	pending := f.pending
+	f.pending = nil
+	f.mu.Unlock()
+
+	for _, s := range pending {
+		err := f.svc.Switch(f.ctx, s.activity, s.at)
+		if err != nil && f.opts.OnError != nil {
+			f.opts.OnError(err)
+		}
+	}
+}
+
+func (f *Focus) event(kind FocusEventKind, at time.Time) {
+	session := *f.session
+	f.svc.SetFocusSession(&session)
+
+	if f.opts.OnEvent != nil {
+		f.opts.OnEvent(FocusEvent{
+			Kind:    kind,
+			Session: session,
+			At:      at,
+		})
+	}
+}
+
+func focusSessionProto(session *FocusSession, now time.Time) (*zeid.FocusSession, error) {
+	if session == nil {
+		return nil, nil
+	}
+
+	endsAt, err := ptypes.TimestampProto(session.EndsAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert focus phase end")
+	}
+
+	remaining := session.EndsAt.Sub(now)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	phase := zeid.FocusPhase_FOCUS_WORK
+	if session.Phase == FocusBreak {
+		phase = zeid.FocusPhase_FOCUS_BREAK
+	}
+
+	return &zeid.FocusSession{
+		Activity: &zeid.Activity{
+			Id:          session.Activity.ID,
+			Name:        session.Activity.Name,
+			Color:       session.Activity.Color,
+			Integration: session.Activity.Integration,
+			DeviceSide:  int64(session.Activity.DeviceSide),
+		},
+		Phase:     phase,
+		Cycle:     int64(session.Cycle),
+		Cycles:    int64(session.Cycles),
+		EndsAt:    endsAt,
+		Remaining: ptypes.DurationProto(remaining),
+	}, nil
+}
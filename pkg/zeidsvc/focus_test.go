@@ -0,0 +1,136 @@
+package zeidsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/zei"
+)
+
+type focusTest struct {
+	t     *testing.T
+	clock *clock.Fake
+	svc   *flipSvc
+	focus *Focus
+
+	events []FocusEvent
+}
+
+// newFocusTest runs sessions of coding, with breaks tracked as meetings
+// when breakSide is set.
+func newFocusTest(t *testing.T, cycles, breakSide int) *focusTest {
+	ft := &focusTest{
+		t:     t,
+		clock: clock.NewFake(time.Date(2019, time.March, 4, 9, 0, 0, 0, time.UTC)),
+		svc:   &flipSvc{current: coding},
+	}
+
+	ft.focus = NewFocus(context.Background(), ft.svc, FocusOptions{
+		Side:      coding.DeviceSide,
+		Work:      25 * time.Minute,
+		Break:     5 * time.Minute,
+		Cycles:    cycles,
+		BreakSide: breakSide,
+		Clock:     ft.clock,
+		// events are only appended by Changed and Advance.
+		OnEvent: func(e FocusEvent) {
+			ft.events = append(ft.events, e)
+		},
+		OnError: func(err error) {
+			t.Errorf("unexpected error: %v", err)
+		},
+	})
+
+	// switching must not wait for the session.
+	ft.svc.onSwitch = func(a zei.Activity, at time.Time) {
+		ft.focus.mu.Lock()
+		ft.focus.mu.Unlock()
+	}
+
+	return ft
+}
+
+// assertEvents checks the events sent since the last call.
+func (ft *focusTest) assertEvents(want ...FocusEvent) {
+	ft.t.Helper()
+
+	if len(ft.events) != len(want) {
+		ft.t.Fatalf("got %d events %+v, want %d %+v", len(ft.events), ft.events, len(want), want)
+	}
+
+	for i, got := range ft.events {
+		w := want[i]
+		if got.Kind != w.Kind || !got.At.Equal(w.At) || got.Session.Phase != w.Session.Phase || got.Session.Cycle != w.Session.Cycle || !got.Session.EndsAt.Equal(w.Session.EndsAt) {
+			ft.t.Errorf("event %d: got %+v, want %+v", i, got, w)
+		}
+	}
+
+	ft.events = nil
+}
+
+func TestFocusCycles(t *testing.T) {
+	ft := newFocusTest(t, 2, 0)
+	start := ft.clock.Now()
+
+	ft.focus.Changed(meetings, coding)
+	ft.assertEvents(FocusEvent{Kind: FocusStarted, At: start, Session: FocusSession{Phase: FocusWork, Cycle: 1, EndsAt: start.Add(25 * time.Minute)}})
+
+	advance(t, ft.clock, 25*time.Minute)
+	ft.assertEvents(FocusEvent{Kind: FocusBreakStarted, At: start.Add(25 * time.Minute), Session: FocusSession{Phase: FocusBreak, Cycle: 1, EndsAt: start.Add(30 * time.Minute)}})
+
+	advance(t, ft.clock, 5*time.Minute)
+	ft.assertEvents(FocusEvent{Kind: FocusStarted, At: start.Add(30 * time.Minute), Session: FocusSession{Phase: FocusWork, Cycle: 2, EndsAt: start.Add(55 * time.Minute)}})
+
+	advance(t, ft.clock, 25*time.Minute)
+	ft.assertEvents(FocusEvent{Kind: FocusEnded, At: start.Add(55 * time.Minute), Session: FocusSession{Phase: FocusWork, Cycle: 2, EndsAt: start.Add(55 * time.Minute)}})
+
+	if ft.svc.focus != nil {
+		t.Errorf("got focus session %+v once ended, want none", ft.svc.focus)
+	}
+
+	// the activity is tracked during breaks without a break side.
+	ft.svc.assertSwitches(t)
+}
+
+func TestFocusBreakSide(t *testing.T) {
+	ft := newFocusTest(t, 0, meetings.DeviceSide)
+	start := ft.clock.Now()
+
+	ft.focus.Changed(meetings, coding)
+	ft.assertEvents(FocusEvent{Kind: FocusStarted, At: start, Session: FocusSession{Phase: FocusWork, Cycle: 1, EndsAt: start.Add(25 * time.Minute)}})
+
+	advance(t, ft.clock, 25*time.Minute)
+	ft.assertEvents(FocusEvent{Kind: FocusBreakStarted, At: start.Add(25 * time.Minute), Session: FocusSession{Phase: FocusBreak, Cycle: 1, EndsAt: start.Add(30 * time.Minute)}})
+	ft.svc.assertSwitches(t, flip{meetings, start.Add(25 * time.Minute)})
+
+	advance(t, ft.clock, 5*time.Minute)
+	ft.assertEvents(FocusEvent{Kind: FocusStarted, At: start.Add(30 * time.Minute), Session: FocusSession{Phase: FocusWork, Cycle: 2, EndsAt: start.Add(55 * time.Minute)}})
+	ft.svc.assertSwitches(t, flip{meetings, start.Add(25 * time.Minute)}, flip{coding, start.Add(30 * time.Minute)})
+
+	if ft.svc.focus == nil || ft.svc.focus.Cycle != 2 {
+		t.Errorf("got focus session %+v, want the second cycle", ft.svc.focus)
+	}
+}
+
+func TestFocusFlippedAway(t *testing.T) {
+	ft := newFocusTest(t, 0, 0)
+	start := ft.clock.Now()
+
+	ft.focus.Changed(meetings, coding)
+	ft.assertEvents(FocusEvent{Kind: FocusStarted, At: start, Session: FocusSession{Phase: FocusWork, Cycle: 1, EndsAt: start.Add(25 * time.Minute)}})
+
+	advance(t, ft.clock, 10*time.Minute)
+	ft.focus.Changed(coding, meetings)
+	ft.assertEvents(FocusEvent{Kind: FocusEnded, At: start.Add(10 * time.Minute), Session: FocusSession{Phase: FocusWork, Cycle: 1, EndsAt: start.Add(25 * time.Minute)}})
+
+	if ft.svc.focus != nil {
+		t.Errorf("got focus session %+v once ended, want none", ft.svc.focus)
+	}
+
+	// the session doesn't go on once ended.
+	advance(t, ft.clock, time.Hour)
+	ft.assertEvents()
+	ft.svc.assertSwitches(t)
+}
@@ -0,0 +1,88 @@
+package zeidsvc
+
+import (
+	"context"
+	"time"
+
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+)
+
+// healthTimeout bounds the request checking the API, so a health check
+// answers even when the API is unreachable.
+const healthTimeout = 5 * time.Second
+
+// RecordOrientation records the time at which the device last reported its
+// orientation.
+func (z *zeisvc) RecordOrientation(at time.Time) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	z.lastOrientation = at
+}
+
+// SetQueueDepth sets the function returning the number of activity changes
+// waiting to be tracked.
+func (z *zeisvc) SetQueueDepth(depth func() int) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	z.queueDepth = depth
+}
+
+// Health checks the connections to the device and to the API of its
+// account.
+func (z *zeisvc) Health(ctx context.Context, req *zeid.HealthReq) (*zeid.HealthResp, error) {
+	z.mu.RLock()
+	lastOrientation, queueDepth := z.lastOrientation, z.queueDepth
+	z.mu.RUnlock()
+
+	health := &zeid.DeviceHealth{
+		Serial:    z.serial,
+		Connected: z.DeviceConnected(),
+		Profile:   z.Profile(),
+	}
+
+	if health.Connected {
+		health.Rssi = int64(z.bleConn.ReadRSSI())
+	}
+
+	if queueDepth != nil {
+		health.QueueDepth = int64(queueDepth())
+	}
+
+	if !lastOrientation.IsZero() {
+		at, err := ptypes.TimestampProto(lastOrientation)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert last orientation time")
+		}
+		health.LastOrientation = at
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	start := z.clock.Now()
+	_, err := z.client().Activities(ctx, z.accessToken())
+	health.ApiLatency = ptypes.DurationProto(z.clock.Since(start))
+
+	switch {
+	case err == nil:
+		health.ApiReachable = true
+		health.TokenChecked = true
+		health.TokenValid = true
+	case zei.IsUnauthorized(err):
+		health.ApiReachable = true
+		health.TokenChecked = true
+		health.ApiError = err.Error()
+	default:
+		// the API may fail before checking the token.
+		_, answered := err.(*zei.StatusError)
+		health.ApiReachable = answered
+		health.ApiError = err.Error()
+	}
+
+	return &zeid.HealthResp{Devices: []*zeid.DeviceHealth{health}}, nil
+}
@@ -0,0 +1,75 @@
+package zeidsvc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/zei"
+)
+
+// rssiClient is a connected BLE connection reporting a fixed signal.
+type rssiClient struct {
+	connectedClient
+}
+
+func (rssiClient) ReadRSSI() int {
+	return -60
+}
+
+func TestHealthToken(t *testing.T) {
+	cases := []struct {
+		name      string
+		status    int
+		closed    bool
+		reachable bool
+		checked   bool
+		valid     bool
+	}{
+		{name: "valid", status: http.StatusOK, reachable: true, checked: true, valid: true},
+		{name: "unauthorized", status: http.StatusUnauthorized, reachable: true, checked: true},
+		{name: "server error", status: http.StatusInternalServerError, reachable: true},
+		{name: "unreachable", closed: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(c.status)
+				w.Write([]byte(`{"activities": []}`))
+			}))
+			defer api.Close()
+
+			if c.closed {
+				api.Close()
+			}
+
+			svc := &zeisvc{
+				serial:  "Z1",
+				clock:   clock.NewFake(time.Date(2019, time.March, 4, 9, 0, 0, 0, time.UTC)),
+				api:     zei.NewClientWithBaseURL(api.URL),
+				bleConn: rssiClient{},
+			}
+			svc.SetQueueDepth(func() int { return 2 })
+
+			res, err := svc.Health(context.Background(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			health := res.Devices[0]
+			if health.ApiReachable != c.reachable || health.TokenChecked != c.checked || health.TokenValid != c.valid {
+				t.Errorf(
+					"got reachable %t, token checked %t and valid %t, want %t, %t and %t",
+					health.ApiReachable, health.TokenChecked, health.TokenValid, c.reachable, c.checked, c.valid,
+				)
+			}
+			if health.QueueDepth != 2 {
+				t.Errorf("got queue depth %d, want 2", health.QueueDepth)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package zeidsvc
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+)
+
+// entry is a time entry clipped to the interval of a history, running
+// entries have no stop time.
+type entry struct {
+	id        string
+	activity  zei.Activity
+	startedAt time.Time
+	stoppedAt time.Time
+}
+
+func (e entry) duration(now time.Time) time.Duration {
+	if e.stoppedAt.IsZero() {
+		return now.Sub(e.startedAt)
+	}
+
+	return e.stoppedAt.Sub(e.startedAt)
+}
+
+// clipEntries converts time entries of the API to entries between from and
+// to, most recent first.
+func clipEntries(timeEntries []zei.TimeEntry, from, to time.Time) ([]entry, error) {
+	entries := make([]entry, 0, len(timeEntries))
+
+	for _, te := range timeEntries {
+		startedAt, err := te.Duration.StartTime()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse entry start time")
+		}
+
+		stoppedAt, err := te.Duration.StopTime()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse entry stop time")
+		}
+
+		if startedAt.Before(from) {
+			startedAt = from
+		}
+		if stoppedAt.After(to) {
+			stoppedAt = to
+		}
+		if !stoppedAt.After(startedAt) {
+			continue
+		}
+
+		entries = append(entries, entry{
+			id:        te.ID,
+			activity:  te.Activity,
+			startedAt: startedAt,
+			stoppedAt: stoppedAt,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].startedAt.After(entries[j].startedAt)
+	})
+
+	return entries, nil
+}
+
+func historyProto(entries []entry, now time.Time) (*zeid.HistoryResp, error) {
+	var (
+		res    = &zeid.HistoryResp{}
+		total  time.Duration
+		totals = map[string]*zeid.ActivityTotal{}
+		sums   = map[string]time.Duration{}
+	)
+
+	for _, e := range entries {
+		activity := &zeid.Activity{
+			Id:          e.activity.ID,
+			Name:        e.activity.Name,
+			Color:       e.activity.Color,
+			Integration: e.activity.Integration,
+			DeviceSide:  int64(e.activity.DeviceSide),
+		}
+
+		startedAt, err := ptypes.TimestampProto(e.startedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert entry start time")
+		}
+
+		te := &zeid.TimeEntry{
+			Id:        e.id,
+			Activity:  activity,
+			StartedAt: startedAt,
+		}
+
+		if !e.stoppedAt.IsZero() {
+			te.StoppedAt, err = ptypes.TimestampProto(e.stoppedAt)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to convert entry stop time")
+			}
+		}
+
+		res.Entries = append(res.Entries, te)
+
+		d := e.duration(now)
+		total += d
+		sums[e.activity.ID] += d
+
+		if _, ok := totals[e.activity.ID]; !ok {
+			totals[e.activity.ID] = &zeid.ActivityTotal{Activity: activity}
+			res.Totals = append(res.Totals, totals[e.activity.ID])
+		}
+	}
+
+	for id, t := range totals {
+		t.Duration = ptypes.DurationProto(sums[id])
+	}
+
+	sort.SliceStable(res.Totals, func(i, j int) bool {
+		return sums[res.Totals[i].Activity.Id] > sums[res.Totals[j].Activity.Id]
+	})
+
+	res.Total = ptypes.DurationProto(total)
+
+	return res, nil
+}
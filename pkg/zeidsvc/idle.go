@@ -0,0 +1,209 @@
+package zeidsvc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/zei"
+)
+
+// IdleOptions configures the automatic stop of tracking while the desktop
+// session is idle.
+type IdleOptions struct {
+	// Timeout is how long the session must be idle before tracking is
+	// stopped.
+	Timeout time.Duration
+
+	// Clock is the source of time, the system clock is used when nil.
+	Clock clock.Clock
+
+	// OnStop is called when tracking of an activity was stopped at the
+	// time the session became idle, or at the start of the activity when it
+	// was started later.
+	OnStop func(a zei.Activity, since time.Time)
+
+	// OnResume is called when tracking resumed after the session became
+	// active again.
+	OnResume func(gap IdleGap)
+
+	// OnError is called when stopping or resuming tracking failed.
+	OnError func(err error)
+}
+
+// IdleGap is the time during which an activity was not tracked because the
+// session was idle.
+type IdleGap struct {
+	Activity zei.Activity
+	From     time.Time
+	To       time.Time
+}
+
+// IdleStopper stops tracking retroactively once the desktop session has been
+// idle for a while, and resumes it when the session is active again.
+type IdleStopper struct {
+	ctx   context.Context
+	svc   ZeiSvc
+	opts  IdleOptions
+	clock clock.Clock
+
+	mu      sync.Mutex
+	timer   clock.Timer
+	stopped *zei.Activity
+	since   time.Time
+
+	// idle and changedAt are the last state of the session reported.
+	idle      bool
+	changedAt time.Time
+}
+
+// NewIdleStopper returns an IdleStopper of the activities tracked by svc.
+func NewIdleStopper(ctx context.Context, svc ZeiSvc, opts IdleOptions) *IdleStopper {
+	return &IdleStopper{
+		ctx:   ctx,
+		svc:   svc,
+		opts:  opts,
+		clock: clock.OrReal(opts.Clock),
+	}
+}
+
+// Idle must be called when the session becomes idle or active, since is the
+// time of the change.
+func (s *IdleStopper) Idle(idle bool, since time.Time) {
+	s.mu.Lock()
+
+	s.idle, s.changedAt = idle, since
+
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+
+	if !idle {
+		gap, ok := s.takeGap(since)
+		s.mu.Unlock()
+
+		if ok {
+			s.resume(gap)
+		}
+		return
+	}
+
+	if s.opts.Timeout <= 0 || s.stopped != nil {
+		s.mu.Unlock()
+		return
+	}
+
+	var timer clock.Timer
+	timer = s.clock.AfterFunc(s.opts.Timeout-s.clock.Since(since), func() {
+		s.mu.Lock()
+		if s.timer != timer {
+			s.mu.Unlock()
+			return
+		}
+		s.timer = nil
+		s.mu.Unlock()
+
+		s.stop(since)
+	})
+	s.timer = timer
+
+	s.mu.Unlock()
+}
+
+// Stop cancels a pending stop of tracking.
+func (s *IdleStopper) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+}
+
+// stop stops tracking from the time the session became idle, or from the
+// start of the activity when it was started later as flipping the device
+// doesn't make the session active.
+func (s *IdleStopper) stop(since time.Time) {
+	current := s.svc.Current()
+	if current.Name == idleActivity.Name {
+		return
+	}
+
+	at := since
+	if start := s.svc.StartTime(); start.After(at) {
+		at = start
+	}
+
+	err := s.svc.Switch(s.ctx, idleActivity, at)
+	if err != nil {
+		s.onError(err)
+		return
+	}
+
+	s.mu.Lock()
+	s.stopped = &current
+	s.since = at
+
+	// the session became active while tracking was stopped.
+	var (
+		gap    IdleGap
+		resume bool
+	)
+	if !s.idle {
+		gap, resume = s.takeGap(s.changedAt)
+	}
+	s.mu.Unlock()
+
+	if s.opts.OnStop != nil {
+		s.opts.OnStop(current, at)
+	}
+
+	if resume {
+		s.resume(gap)
+	}
+}
+
+// takeGap returns the gap of the activity stopped while the session was
+// idle, if any, until the given time. s.mu must be held.
+func (s *IdleStopper) takeGap(to time.Time) (IdleGap, bool) {
+	if s.stopped == nil {
+		return IdleGap{}, false
+	}
+
+	gap := IdleGap{
+		Activity: *s.stopped,
+		From:     s.since,
+		To:       to,
+	}
+	s.stopped = nil
+
+	return gap, true
+}
+
+func (s *IdleStopper) resume(gap IdleGap) {
+	// the activity was changed while the session was idle.
+	if s.svc.Current().Name != idleActivity.Name {
+		return
+	}
+
+	err := s.svc.Switch(s.ctx, gap.Activity, gap.To)
+	if err != nil {
+		s.onError(err)
+		return
+	}
+
+	s.svc.SetIdleGap(gap)
+
+	if s.opts.OnResume != nil {
+		s.opts.OnResume(gap)
+	}
+}
+
+func (s *IdleStopper) onError(err error) {
+	if s.opts.OnError != nil {
+		s.opts.OnError(err)
+	}
+}
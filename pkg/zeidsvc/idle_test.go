@@ -0,0 +1,95 @@
+package zeidsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/zei"
+)
+
+func newIdleTest(t *testing.T, svc *flipSvc) (*clock.Fake, *IdleStopper) {
+	c := clock.NewFake(time.Date(2019, time.March, 4, 9, 0, 0, 0, time.UTC))
+
+	stopper := NewIdleStopper(context.Background(), svc, IdleOptions{
+		Timeout: 5 * time.Minute,
+		Clock:   c,
+		OnError: func(err error) {
+			t.Errorf("unexpected error: %v", err)
+		},
+	})
+
+	return c, stopper
+}
+
+// advance moves the clock forward, failing when the stopper deadlocks.
+func advance(t *testing.T, c *clock.Fake, d time.Duration) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		c.Advance(d)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the clock is still advancing after 1s")
+	}
+}
+
+func TestIdleStopperStopsAtIdleTime(t *testing.T) {
+	svc := &flipSvc{current: coding}
+	c, stopper := newIdleTest(t, svc)
+	svc.since = c.Now().Add(-time.Hour)
+
+	idleSince := c.Now()
+	stopper.Idle(true, idleSince)
+	advance(t, c, 5*time.Minute)
+
+	svc.assertSwitches(t, flip{idleActivity, idleSince})
+}
+
+func TestIdleStopperStopsAtActivityStart(t *testing.T) {
+	svc := &flipSvc{current: coding}
+	c, stopper := newIdleTest(t, svc)
+
+	idleSince := c.Now()
+	stopper.Idle(true, idleSince)
+
+	// the device is flipped while the session is idle.
+	advance(t, c, 2*time.Minute)
+	started := c.Now()
+	svc.Switch(context.Background(), meetings, started)
+
+	advance(t, c, 3*time.Minute)
+
+	svc.assertSwitches(t, flip{meetings, started}, flip{idleActivity, started})
+}
+
+func TestIdleStopperResumesWhileStopping(t *testing.T) {
+	svc := &flipSvc{current: coding}
+	c, stopper := newIdleTest(t, svc)
+	svc.since = c.Now().Add(-time.Hour)
+
+	idleSince := c.Now()
+	stopper.Idle(true, idleSince)
+
+	// the session becomes active while tracking is being stopped.
+	activeSince := idleSince.Add(5 * time.Minute)
+	svc.onSwitch = func(a zei.Activity, at time.Time) {
+		if a.ID == idleActivity.ID {
+			stopper.Idle(false, activeSince)
+		}
+	}
+
+	advance(t, c, 5*time.Minute)
+
+	svc.assertSwitches(t, flip{idleActivity, idleSince}, flip{coding, activeSince})
+
+	if svc.gap == nil || !svc.gap.From.Equal(idleSince) || !svc.gap.To.Equal(activeSince) {
+		t.Errorf("got idle gap %+v, want from %s to %s", svc.gap, idleSince, activeSince)
+	}
+}
@@ -0,0 +1,205 @@
+package zeidsvc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/pkg/errors"
+)
+
+// applyLayout reconciles the sides of the device with layout, the changes
+// are only planned on dry runs.
+func (z *zeisvc) applyLayout(ctx context.Context, layout []*zeid.LayoutSide, dryRun bool) ([]layoutChange, error) {
+	api, token := z.client(), z.accessToken()
+
+	activities, err := api.Activities(ctx, token)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query ZEI activities")
+	}
+
+	sides := map[int]zei.Activity{}
+	for side := minSide; side <= maxSide; side++ {
+		if a, ok := z.GetActivity(side); ok {
+			sides[side] = a
+		}
+	}
+
+	changes, err := planLayout(activities, sides, layout)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid layout")
+	}
+
+	if dryRun {
+		return changes, nil
+	}
+
+	// sides are freed before activities are assigned to them.
+	for _, change := range changes {
+		previous := change.previous
+		if change.kind == zeid.LayoutChangeKind_LAYOUT_UNASSIGN {
+			previous = &change.activity
+		}
+		if previous == nil {
+			continue
+		}
+
+		err = api.UnassignActivity(ctx, token, previous.ID, change.side)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unassign side %d", change.side)
+		}
+	}
+
+	for i, change := range changes {
+		activity := change.activity
+
+		switch change.kind {
+		case zeid.LayoutChangeKind_LAYOUT_UNASSIGN:
+			continue
+		case zeid.LayoutChangeKind_LAYOUT_CREATE:
+			created, err := api.CreateActivity(ctx, token, activity.Name, activity.Color)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to create activity %q", activity.Name)
+			}
+			activity = *created
+		}
+
+		assigned, err := api.AssignActivity(ctx, token, activity.ID, change.side)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to assign side %d", change.side)
+		}
+		changes[i].activity = *assigned
+	}
+
+	err = z.reloadActivities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// layoutChange is a change of a side reconciling the device with a layout.
+type layoutChange struct {
+	kind     zeid.LayoutChangeKind
+	side     int
+	activity zei.Activity
+	// previous is the activity of the side before the change, if any.
+	previous *zei.Activity
+}
+
+// planLayout returns the changes turning sides into the given layout, sides
+// which are not part of the layout are unassigned. Unassignments come first,
+// as they are applied.
+func planLayout(activities []zei.Activity, sides map[int]zei.Activity, layout []*zeid.LayoutSide) ([]layoutChange, error) {
+	declared := map[int]*zeid.LayoutSide{}
+	names := map[string]int{}
+
+	for _, s := range layout {
+		side := int(s.Number)
+		if side < minSide || side > maxSide {
+			return nil, invalidArgumentError("sides", "side must be between %d and %d", minSide, maxSide)
+		}
+		if _, ok := declared[side]; ok {
+			return nil, invalidArgumentError("sides", "side %d is declared twice", side)
+		}
+		if s.ActivityName == "" {
+			return nil, invalidArgumentError("sides", "side %d has no activity name", side)
+		}
+
+		name := strings.ToLower(s.ActivityName)
+		if other, ok := names[name]; ok {
+			return nil, invalidArgumentError("sides", "activity %q is declared on sides %d and %d", s.ActivityName, other, side)
+		}
+
+		declared[side] = s
+		names[name] = side
+	}
+
+	var unassigns, changes []layoutChange
+
+	for side := minSide; side <= maxSide; side++ {
+		var previous *zei.Activity
+		if a, ok := sides[side]; ok && a.Name != idleActivity.Name {
+			previous = &a
+		}
+
+		s, ok := declared[side]
+		if !ok {
+			if previous != nil {
+				unassigns = append(unassigns, layoutChange{
+					kind:     zeid.LayoutChangeKind_LAYOUT_UNASSIGN,
+					side:     side,
+					activity: *previous,
+				})
+			}
+			continue
+		}
+
+		activity := findActivityByName(activities, s.ActivityName)
+		if activity == nil {
+			changes = append(changes, layoutChange{
+				kind: zeid.LayoutChangeKind_LAYOUT_CREATE,
+				side: side,
+				activity: zei.Activity{
+					Name:  s.ActivityName,
+					Color: s.Color,
+				},
+				previous: previous,
+			})
+			continue
+		}
+
+		if previous != nil && previous.ID == activity.ID {
+			continue
+		}
+
+		changes = append(changes, layoutChange{
+			kind:     zeid.LayoutChangeKind_LAYOUT_ASSIGN,
+			side:     side,
+			activity: *activity,
+			previous: previous,
+		})
+	}
+
+	return append(unassigns, changes...), nil
+}
+
+// findActivityByName returns the activity with the given name, ignoring
+// case, or nil.
+func findActivityByName(activities []zei.Activity, name string) *zei.Activity {
+	for i := range activities {
+		if strings.EqualFold(activities[i].Name, name) {
+			return &activities[i]
+		}
+	}
+
+	return nil
+}
+
+func layoutChangeProto(change layoutChange) *zeid.LayoutChange {
+	pb := &zeid.LayoutChange{
+		Kind: change.kind,
+		Side: int64(change.side),
+		Activity: &zeid.Activity{
+			Id:          change.activity.ID,
+			Name:        change.activity.Name,
+			Color:       change.activity.Color,
+			Integration: change.activity.Integration,
+			DeviceSide:  int64(change.side),
+		},
+	}
+
+	if change.previous != nil {
+		pb.Previous = &zeid.Activity{
+			Id:          change.previous.ID,
+			Name:        change.previous.Name,
+			Color:       change.previous.Color,
+			Integration: change.previous.Integration,
+			DeviceSide:  int64(change.side),
+		}
+	}
+
+	return pb
+}
@@ -0,0 +1,143 @@
+package zeidsvc
+
+import (
+	"testing"
+
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/twitchtv/twirp"
+)
+
+func TestPlanLayout(t *testing.T) {
+	var (
+		code     = zei.Activity{ID: "1", Name: "Code"}
+		review   = zei.Activity{ID: "2", Name: "Review"}
+		meetings = zei.Activity{ID: "3", Name: "Meetings"}
+	)
+
+	activities := []zei.Activity{code, review, meetings}
+
+	// plan is a change of a side, previous is the ID of the activity it
+	// replaces.
+	type plan struct {
+		kind     zeid.LayoutChangeKind
+		side     int
+		name     string
+		previous string
+	}
+
+	cases := []struct {
+		name   string
+		sides  map[int]zei.Activity
+		layout []*zeid.LayoutSide
+		want   []plan
+		code   twirp.ErrorCode
+	}{
+		{
+			name:  "unchanged",
+			sides: map[int]zei.Activity{1: code, 2: review},
+			layout: []*zeid.LayoutSide{
+				{Number: 1, ActivityName: "code"},
+				{Number: 2, ActivityName: "Review"},
+			},
+		},
+		{
+			name:  "creates missing activities",
+			sides: map[int]zei.Activity{1: code},
+			layout: []*zeid.LayoutSide{
+				{Number: 1, ActivityName: "Code"},
+				{Number: 2, ActivityName: "Lunch", Color: "#00ff00"},
+			},
+			want: []plan{
+				{kind: zeid.LayoutChangeKind_LAYOUT_CREATE, side: 2, name: "Lunch"},
+			},
+		},
+		{
+			name:  "reassigns sides",
+			sides: map[int]zei.Activity{1: code, 2: idleActivity},
+			layout: []*zeid.LayoutSide{
+				{Number: 1, ActivityName: "Meetings"},
+				{Number: 2, ActivityName: "Review"},
+			},
+			want: []plan{
+				{kind: zeid.LayoutChangeKind_LAYOUT_ASSIGN, side: 1, name: "Meetings", previous: code.ID},
+				{kind: zeid.LayoutChangeKind_LAYOUT_ASSIGN, side: 2, name: "Review"},
+			},
+		},
+		{
+			name:  "frees sides first",
+			sides: map[int]zei.Activity{1: code, 2: review, 3: meetings},
+			layout: []*zeid.LayoutSide{
+				{Number: 2, ActivityName: "Code"},
+			},
+			want: []plan{
+				{kind: zeid.LayoutChangeKind_LAYOUT_UNASSIGN, side: 1, name: "Code"},
+				{kind: zeid.LayoutChangeKind_LAYOUT_UNASSIGN, side: 3, name: "Meetings"},
+				{kind: zeid.LayoutChangeKind_LAYOUT_ASSIGN, side: 2, name: "Code", previous: review.ID},
+			},
+		},
+		{
+			name: "rejects duplicate sides",
+			layout: []*zeid.LayoutSide{
+				{Number: 1, ActivityName: "Code"},
+				{Number: 1, ActivityName: "Review"},
+			},
+			code: twirp.InvalidArgument,
+		},
+		{
+			name: "rejects duplicate names",
+			layout: []*zeid.LayoutSide{
+				{Number: 1, ActivityName: "Code"},
+				{Number: 2, ActivityName: "CODE"},
+			},
+			code: twirp.InvalidArgument,
+		},
+		{
+			name: "rejects invalid sides",
+			layout: []*zeid.LayoutSide{
+				{Number: 9, ActivityName: "Code"},
+			},
+			code: twirp.InvalidArgument,
+		},
+		{
+			name: "rejects sides without activity",
+			layout: []*zeid.LayoutSide{
+				{Number: 1},
+			},
+			code: twirp.InvalidArgument,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			changes, err := planLayout(activities, c.sides, c.layout)
+
+			if c.code != twirp.NoError {
+				twerr, ok := err.(twirp.Error)
+				if !ok || twerr.Code() != c.code {
+					t.Fatalf("got changes %+v and error %v, want a %s error", changes, err, c.code)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(changes) != len(c.want) {
+				t.Fatalf("got %d changes %+v, want %d %+v", len(changes), changes, len(c.want), c.want)
+			}
+
+			for i, change := range changes {
+				got := plan{kind: change.kind, side: change.side, name: change.activity.Name}
+				if change.previous != nil {
+					got.previous = change.previous.ID
+				}
+
+				if got != c.want[i] {
+					t.Errorf("change %d: got %+v, want %+v", i, got, c.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package zeidsvc
+
+import (
+	"context"
+
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/pkg/errors"
+)
+
+// DefaultProfile is the name of the profile active until another one is
+// used, it tracks to the account zeid was started with.
+const DefaultProfile = "default"
+
+// Profile is a named layout of the sides of the device. It tracks to the
+// account zeid was started with, unless it has its own API key, and it keeps
+// the sides of the account untouched when it has no layout.
+type Profile struct {
+	Name      string
+	APIURL    string
+	APIKey    string
+	APISecret string
+	Layout    []*zeid.LayoutSide
+}
+
+// account identifies the backend and credentials time is tracked with.
+type account struct {
+	apiURL    string
+	apiKey    string
+	apiSecret string
+}
+
+func (p Profile) ownAccount() bool {
+	return p.APIKey != ""
+}
+
+// account returns the account of the profile, fallback when it has none.
+func (p Profile) account(fallback account) account {
+	a := fallback
+	if p.ownAccount() {
+		a = account{apiKey: p.APIKey, apiSecret: p.APISecret}
+	}
+	if p.APIURL != "" {
+		a.apiURL = p.APIURL
+	}
+	if a.apiURL == "" {
+		a.apiURL = zei.DefaultBaseURL
+	}
+
+	return a
+}
+
+// client returns the client of the API of the active profile.
+func (z *zeisvc) client() *zei.Client {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	return z.api
+}
+
+// accessToken returns the access token of the account of the active
+// profile.
+func (z *zeisvc) accessToken() string {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	return z.token
+}
+
+// Profile returns the name of the active profile.
+func (z *zeisvc) Profile() string {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	return z.profile
+}
+
+// SetProfiles replaces the profiles which can be used, onSwitch is called
+// with the name of a profile once it is used.
+func (z *zeisvc) SetProfiles(profiles []Profile, onSwitch func(name string)) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	z.profiles = profiles
+	z.onSwitch = onSwitch
+}
+
+// UseProfile makes the named profile active.
+func (z *zeisvc) UseProfile(ctx context.Context, name string) error {
+	_, _, err := z.useProfile(ctx, name)
+	return err
+}
+
+// useProfile signs in to the account of the profile and applies its layout,
+// tracking is only switched when the activity of the current side changes.
+func (z *zeisvc) useProfile(ctx context.Context, name string) (Profile, []layoutChange, error) {
+	z.profileMu.Lock()
+	defer z.profileMu.Unlock()
+
+	profile, ok := z.findProfile(name)
+	if !ok {
+		return Profile{}, nil, notFoundError("unknown profile %q", name)
+	}
+
+	now := z.clock.Now()
+
+	z.mu.RLock()
+	current, next := z.account, profile.account(z.fallback)
+	z.mu.RUnlock()
+
+	if next != current {
+		api := zei.NewClientWithBaseURL(next.apiURL)
+		api.SetObserver(z.observe)
+
+		token, err := api.DeveloperSignIn(ctx, next.apiKey, next.apiSecret)
+		if err != nil {
+			return Profile{}, nil, errors.Wrapf(err, "failed to sign-in to the account of profile %q", name)
+		}
+
+		// tracking is stopped on the previous account, and is not started
+		// again before the account changed.
+		z.switchMu.Lock()
+		err = z.switchLocked(ctx, idleActivity, now)
+		if err != nil {
+			z.switchMu.Unlock()
+			return Profile{}, nil, errors.Wrap(err, "failed to stop tracking")
+		}
+
+		z.mu.Lock()
+		z.api = api
+		z.token = token
+		z.account = next
+		z.mu.Unlock()
+		z.switchMu.Unlock()
+	}
+
+	var (
+		changes []layoutChange
+		err     error
+	)
+	if len(profile.Layout) > 0 {
+		changes, err = z.applyLayout(ctx, profile.Layout, false)
+	} else {
+		err = z.reloadActivities(ctx)
+	}
+	if err != nil {
+		return Profile{}, nil, errors.Wrapf(err, "failed to apply the layout of profile %q", name)
+	}
+
+	z.mu.Lock()
+	z.profile = profile.Name
+	onSwitch := z.onSwitch
+	z.mu.Unlock()
+
+	if onSwitch != nil {
+		onSwitch(profile.Name)
+	}
+
+	side, err := z.GetCurrentSide()
+	if err != nil {
+		return Profile{}, nil, errors.Wrap(err, "failed to read current Timeular side")
+	}
+
+	if activity, ok := z.GetActivity(side); ok && activity.ID != z.Current().ID {
+		err = z.Switch(ctx, activity, now)
+		if err != nil {
+			return Profile{}, nil, errors.Wrap(err, "failed to switch tracking")
+		}
+	}
+
+	return profile, changes, nil
+}
+
+func (z *zeisvc) findProfile(name string) (Profile, bool) {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	for _, p := range z.profiles {
+		if p.Name == name {
+			return p, true
+		}
+	}
+
+	return Profile{}, false
+}
+
+// ListProfiles returns the profiles which can be used and the active one.
+func (z *zeisvc) ListProfiles(ctx context.Context, req *zeid.ListProfilesReq) (*zeid.ListProfilesResp, error) {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	res := &zeid.ListProfilesResp{
+		Profiles: make([]*zeid.Profile, 0, len(z.profiles)),
+		Active:   z.profile,
+	}
+
+	for _, p := range z.profiles {
+		res.Profiles = append(res.Profiles, profileProto(p))
+	}
+
+	return res, nil
+}
+
+// SwitchProfile makes a profile active without reconnecting to the device.
+func (z *zeisvc) SwitchProfile(ctx context.Context, req *zeid.SwitchProfileReq) (*zeid.SwitchProfileResp, error) {
+	if req.Name == "" {
+		return nil, invalidArgumentError("name", "a profile name is required")
+	}
+
+	profile, changes, err := z.useProfile(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &zeid.SwitchProfileResp{
+		Profile: profileProto(profile),
+		Changes: make([]*zeid.LayoutChange, 0, len(changes)),
+	}
+
+	for _, change := range changes {
+		res.Changes = append(res.Changes, layoutChangeProto(change))
+	}
+
+	return res, nil
+}
+
+func profileProto(p Profile) *zeid.Profile {
+	return &zeid.Profile{
+		Name:       p.Name,
+		OwnAccount: p.ownAccount(),
+		Layout:     p.Layout,
+	}
+}
@@ -0,0 +1,356 @@
+package zeidsvc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/zei"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/golang/protobuf/ptypes"
+	durpb "github.com/golang/protobuf/ptypes/duration"
+)
+
+// ReminderRules are the rules reminding of forgotten tracking. Times of day
+// are expressed as the time elapsed since midnight, midnight being a valid
+// time of day the rules using them are enabled explicitly. Zero durations
+// disable the other rules.
+type ReminderRules struct {
+	// LongTracking is the duration of continuous tracking of an activity
+	// after which a reminder is sent, and again for each multiple of it.
+	LongTracking time.Duration
+
+	// EndOfDay is the time of day at which tracking is stopped, when
+	// StopAtEndOfDay is set. Midnight is the end of the day the activity
+	// was started, activities started after the end of the day are stopped
+	// right away.
+	StopAtEndOfDay bool
+	EndOfDay       time.Duration
+
+	// WorkStart and WorkEnd delimit the working hours of week days, when
+	// HasWorkHours is set.
+	HasWorkHours bool
+	WorkStart    time.Duration
+	WorkEnd      time.Duration
+
+	// UntrackedAfter is how long nothing must have been tracked during
+	// working hours before a warning is sent.
+	UntrackedAfter time.Duration
+}
+
+// ReminderKind is the rule which triggered a reminder.
+type ReminderKind int
+
+const (
+	// LongTrackingReminder is sent when an activity has been tracked for
+	// a long time.
+	LongTrackingReminder ReminderKind = iota
+	// EndOfDayReminder is sent when tracking was stopped at the end of the
+	// day.
+	EndOfDayReminder
+	// UntrackedReminder is sent when nothing has been tracked during
+	// working hours.
+	UntrackedReminder
+)
+
+// Reminder is a notification of forgotten tracking.
+type Reminder struct {
+	Kind     ReminderKind
+	Activity zei.Activity
+	// Since is the time at which the activity, or idleness, started.
+	Since time.Time
+	// At is the time the rule was triggered, for end of day reminders it is
+	// the time at which tracking was stopped.
+	At time.Time
+}
+
+// ReminderOptions configures how reminder rules are checked.
+type ReminderOptions struct {
+	// Interval between two checks of the rules, defaults to a minute.
+	Interval time.Duration
+
+	// Clock is the source of time, the system clock is used when nil.
+	Clock clock.Clock
+
+	// Location of the times of day, defaults to the local time zone.
+	Location *time.Location
+
+	// OnReminder is called with each triggered reminder.
+	OnReminder func(r Reminder)
+
+	// OnError is called when stopping tracking at the end of the day
+	// failed.
+	OnError func(err error)
+}
+
+// Reminders periodically checks the reminder rules of a service.
+type Reminders struct {
+	ctx   context.Context
+	svc   ZeiSvc
+	opts  ReminderOptions
+	clock clock.Clock
+
+	mu    sync.Mutex
+	timer clock.Timer
+
+	longStart time.Time
+	longCount int64
+
+	untrackedSince time.Time
+}
+
+// NewReminders returns Reminders checking the rules of svc.
+func NewReminders(ctx context.Context, svc ZeiSvc, opts ReminderOptions) *Reminders {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Minute
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+
+	return &Reminders{
+		ctx:   ctx,
+		svc:   svc,
+		opts:  opts,
+		clock: clock.OrReal(opts.Clock),
+	}
+}
+
+// Start checks the rules now and then at each interval.
+func (r *Reminders) Start() {
+	r.mu.Lock()
+	reminder := r.check()
+	r.schedule()
+	r.mu.Unlock()
+
+	r.notify(reminder)
+}
+
+// Stop stops checking the rules.
+func (r *Reminders) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+}
+
+func (r *Reminders) schedule() {
+	var timer clock.Timer
+	timer = r.clock.AfterFunc(r.opts.Interval, func() {
+		r.mu.Lock()
+		if r.timer != timer {
+			r.mu.Unlock()
+			return
+		}
+
+		reminder := r.check()
+		r.schedule()
+		r.mu.Unlock()
+
+		r.notify(reminder)
+	})
+	r.timer = timer
+}
+
+// check returns the reminder triggered by the rules, if any. r.mu must be
+// held.
+func (r *Reminders) check() *Reminder {
+	var (
+		rules     = r.svc.ReminderRules()
+		now       = r.clock.Now().In(r.opts.Location)
+		current   = r.svc.Current()
+		startTime = r.svc.StartTime().In(r.opts.Location)
+	)
+
+	if current.Name == idleActivity.Name {
+		return r.checkUntracked(rules, now, startTime)
+	}
+
+	if reminder := r.checkEndOfDay(rules, now, current, startTime); reminder != nil {
+		return reminder
+	}
+
+	return r.checkLongTracking(rules, now, current, startTime)
+}
+
+// notify stops tracking for end of day reminders, then sends the reminder.
+// r.mu must not be held.
+func (r *Reminders) notify(reminder *Reminder) {
+	if reminder == nil {
+		return
+	}
+
+	if reminder.Kind == EndOfDayReminder {
+		err := r.svc.Switch(r.ctx, idleActivity, reminder.At)
+		if err != nil {
+			if r.opts.OnError != nil {
+				r.opts.OnError(err)
+			}
+			return
+		}
+	}
+
+	if r.opts.OnReminder != nil {
+		r.opts.OnReminder(*reminder)
+	}
+}
+
+func (r *Reminders) checkLongTracking(rules ReminderRules, now time.Time, current zei.Activity, startTime time.Time) *Reminder {
+	if rules.LongTracking <= 0 {
+		return nil
+	}
+
+	if !startTime.Equal(r.longStart) {
+		r.longStart = startTime
+		r.longCount = 0
+	}
+
+	count := int64(now.Sub(startTime) / rules.LongTracking)
+	if count <= r.longCount {
+		return nil
+	}
+	r.longCount = count
+
+	return &Reminder{
+		Kind:     LongTrackingReminder,
+		Activity: current,
+		Since:    startTime,
+		At:       now,
+	}
+}
+
+func (r *Reminders) checkEndOfDay(rules ReminderRules, now time.Time, current zei.Activity, startTime time.Time) *Reminder {
+	if !rules.StopAtEndOfDay {
+		return nil
+	}
+
+	stopAt := atTimeOfDay(startTime, rules.EndOfDay)
+	if rules.EndOfDay == 0 {
+		stopAt = stopAt.AddDate(0, 0, 1)
+	}
+	if !stopAt.After(startTime) {
+		stopAt = now
+	}
+
+	if stopAt.After(now) {
+		return nil
+	}
+
+	return &Reminder{
+		Kind:     EndOfDayReminder,
+		Activity: current,
+		Since:    startTime,
+		At:       stopAt,
+	}
+}
+
+func (r *Reminders) checkUntracked(rules ReminderRules, now, idleSince time.Time) *Reminder {
+	if rules.UntrackedAfter <= 0 || !rules.HasWorkHours || rules.WorkEnd <= rules.WorkStart {
+		return nil
+	}
+
+	if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
+		return nil
+	}
+
+	workStart, workEnd := atTimeOfDay(now, rules.WorkStart), atTimeOfDay(now, rules.WorkEnd)
+	if now.Before(workStart) || !now.Before(workEnd) {
+		return nil
+	}
+
+	since := idleSince
+	if since.Before(workStart) {
+		since = workStart
+	}
+
+	if now.Sub(since) < rules.UntrackedAfter || r.untrackedSince.Equal(since) {
+		return nil
+	}
+	r.untrackedSince = since
+
+	return &Reminder{
+		Kind:     UntrackedReminder,
+		Activity: idleActivity,
+		Since:    since,
+		At:       now,
+	}
+}
+
+// atTimeOfDay returns the time at the given time of day on the day of t, in
+// the location of t.
+func atTimeOfDay(t time.Time, timeOfDay time.Duration) time.Time {
+	y, m, d := t.Date()
+	hours, minutes := timeOfDay/time.Hour, (timeOfDay%time.Hour)/time.Minute
+
+	return time.Date(y, m, d, int(hours), int(minutes), 0, 0, t.Location())
+}
+
+func reminderRulesProto(rules ReminderRules) *zeid.ReminderRules {
+	pb := &zeid.ReminderRules{
+		LongTracking:   ptypes.DurationProto(rules.LongTracking),
+		UntrackedAfter: ptypes.DurationProto(rules.UntrackedAfter),
+	}
+
+	if rules.StopAtEndOfDay {
+		pb.EndOfDay = ptypes.DurationProto(rules.EndOfDay)
+	}
+
+	if rules.HasWorkHours {
+		pb.WorkStart = ptypes.DurationProto(rules.WorkStart)
+		pb.WorkEnd = ptypes.DurationProto(rules.WorkEnd)
+	}
+
+	return pb
+}
+
+func reminderRulesFromProto(pb *zeid.ReminderRules) (ReminderRules, error) {
+	var rules ReminderRules
+
+	if pb == nil {
+		return rules, nil
+	}
+
+	if (pb.WorkStart == nil) != (pb.WorkEnd == nil) {
+		return rules, invalidArgumentError("rules", "working hours must have a start and an end")
+	}
+
+	rules.StopAtEndOfDay = pb.EndOfDay != nil
+	rules.HasWorkHours = pb.WorkStart != nil
+
+	for _, field := range []struct {
+		pb  *durpb.Duration
+		dst *time.Duration
+	}{
+		{pb.LongTracking, &rules.LongTracking},
+		{pb.EndOfDay, &rules.EndOfDay},
+		{pb.WorkStart, &rules.WorkStart},
+		{pb.WorkEnd, &rules.WorkEnd},
+		{pb.UntrackedAfter, &rules.UntrackedAfter},
+	} {
+		if field.pb == nil {
+			continue
+		}
+
+		d, err := ptypes.Duration(field.pb)
+		if err != nil {
+			return rules, err
+		}
+		if d < 0 {
+			return rules, invalidArgumentError("rules", "durations must be positive")
+		}
+
+		*field.dst = d
+	}
+
+	for _, timeOfDay := range []time.Duration{rules.EndOfDay, rules.WorkStart, rules.WorkEnd} {
+		if timeOfDay >= 24*time.Hour {
+			return rules, invalidArgumentError("rules", "times of day must be before midnight")
+		}
+	}
+
+	return rules, nil
+}
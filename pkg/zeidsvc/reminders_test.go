@@ -0,0 +1,250 @@
+package zeidsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/pkg/zei"
+)
+
+type reminderTest struct {
+	t         *testing.T
+	clock     *clock.Fake
+	svc       *flipSvc
+	reminders *Reminders
+
+	got []Reminder
+}
+
+// midnight is the start of 2019-03-04, a monday.
+var midnight = time.Date(2019, time.March, 4, 0, 0, 0, 0, time.UTC)
+
+// newReminderTest checks the rules each minute from the given time of day,
+// while svc tracks its current activity.
+func newReminderTest(t *testing.T, now time.Duration, svc *flipSvc) *reminderTest {
+	rt := &reminderTest{
+		t:     t,
+		clock: clock.NewFake(midnight.Add(now)),
+		svc:   svc,
+	}
+
+	rt.reminders = NewReminders(context.Background(), svc, ReminderOptions{
+		Clock:    rt.clock,
+		Location: time.UTC,
+		// reminders are only appended by the checks, which are run by
+		// Start and Advance.
+		OnReminder: func(r Reminder) {
+			rt.got = append(rt.got, r)
+		},
+		OnError: func(err error) {
+			t.Errorf("unexpected error: %v", err)
+		},
+	})
+
+	return rt
+}
+
+func (rt *reminderTest) start() {
+	rt.t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		rt.reminders.Start()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		rt.t.Fatal("the reminders are still starting after 1s")
+	}
+}
+
+// assertReminders checks the reminders sent since the last call.
+func (rt *reminderTest) assertReminders(want ...Reminder) {
+	rt.t.Helper()
+
+	if len(rt.got) != len(want) {
+		rt.t.Fatalf("got %d reminders %+v, want %d %+v", len(rt.got), rt.got, len(want), want)
+	}
+
+	for i, got := range rt.got {
+		if got.Kind != want[i].Kind || got.Activity.ID != want[i].Activity.ID || !got.Since.Equal(want[i].Since) || !got.At.Equal(want[i].At) {
+			rt.t.Errorf("reminder %d: got %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	rt.got = nil
+}
+
+func TestRemindersLongTracking(t *testing.T) {
+	svc := &flipSvc{current: coding, rules: ReminderRules{LongTracking: time.Hour}}
+	rt := newReminderTest(t, 10*time.Hour, svc)
+	defer rt.reminders.Stop()
+
+	started := midnight.Add(9*time.Hour + 30*time.Minute)
+	svc.since = started
+
+	rt.start()
+	rt.assertReminders()
+
+	advance(t, rt.clock, 30*time.Minute)
+	rt.assertReminders(Reminder{Kind: LongTrackingReminder, Activity: coding, Since: started, At: started.Add(time.Hour)})
+
+	advance(t, rt.clock, 59*time.Minute)
+	rt.assertReminders()
+
+	advance(t, rt.clock, time.Minute)
+	rt.assertReminders(Reminder{Kind: LongTrackingReminder, Activity: coding, Since: started, At: started.Add(2 * time.Hour)})
+
+	// the count starts again with the next activity.
+	started = rt.clock.Now()
+	svc.Switch(context.Background(), meetings, started)
+
+	advance(t, rt.clock, 59*time.Minute)
+	rt.assertReminders()
+
+	advance(t, rt.clock, time.Minute)
+	rt.assertReminders(Reminder{Kind: LongTrackingReminder, Activity: meetings, Since: started, At: started.Add(time.Hour)})
+}
+
+func TestRemindersEndOfDay(t *testing.T) {
+	cases := []struct {
+		name      string
+		endOfDay  time.Duration
+		startedAt time.Duration
+		now       time.Duration
+		advance   time.Duration
+		stopAt    time.Duration
+	}{
+		{
+			name:      "stops at the end of the day",
+			endOfDay:  19 * time.Hour,
+			startedAt: 17 * time.Hour,
+			now:       18 * time.Hour,
+			advance:   time.Hour,
+			stopAt:    19 * time.Hour,
+		},
+		{
+			name:      "stops activities started after the end of the day",
+			endOfDay:  19 * time.Hour,
+			startedAt: 20 * time.Hour,
+			now:       20 * time.Hour,
+			stopAt:    20 * time.Hour,
+		},
+		{
+			name:      "stops at midnight",
+			endOfDay:  0,
+			startedAt: 22 * time.Hour,
+			now:       23 * time.Hour,
+			advance:   time.Hour,
+			stopAt:    24 * time.Hour,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			svc := &flipSvc{current: coding, rules: ReminderRules{StopAtEndOfDay: true, EndOfDay: c.endOfDay}}
+			rt := newReminderTest(t, c.now, svc)
+			defer rt.reminders.Stop()
+
+			svc.since = midnight.Add(c.startedAt)
+			stopAt := midnight.Add(c.stopAt)
+
+			// the rules are checked again once tracking is stopped.
+			svc.onSwitch = func(a zei.Activity, at time.Time) {
+				rt.reminders.Stop()
+				rt.reminders.Start()
+			}
+
+			rt.start()
+			if c.advance > 0 {
+				advance(t, rt.clock, c.advance-time.Minute)
+				rt.assertReminders()
+
+				advance(t, rt.clock, time.Minute)
+			}
+
+			svc.assertSwitches(t, flip{idleActivity, stopAt})
+			rt.assertReminders(Reminder{Kind: EndOfDayReminder, Activity: coding, Since: midnight.Add(c.startedAt), At: stopAt})
+		})
+	}
+}
+
+func TestRemindersUntracked(t *testing.T) {
+	cases := []struct {
+		name      string
+		workStart time.Duration
+		idleSince time.Duration
+		now       time.Duration
+		since     time.Duration
+	}{
+		{
+			name:      "idle before the working hours",
+			workStart: 9 * time.Hour,
+			idleSince: 8 * time.Hour,
+			now:       9*time.Hour + 30*time.Minute,
+			since:     9 * time.Hour,
+		},
+		{
+			name:      "idle during the working hours",
+			workStart: 9 * time.Hour,
+			idleSince: 10 * time.Hour,
+			now:       10*time.Hour + 30*time.Minute,
+			since:     10 * time.Hour,
+		},
+		{
+			name:      "working hours from midnight",
+			workStart: 0,
+			idleSince: 0,
+			now:       30 * time.Minute,
+			since:     0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			svc := &flipSvc{current: idleActivity, rules: ReminderRules{
+				HasWorkHours:   true,
+				WorkStart:      c.workStart,
+				WorkEnd:        18 * time.Hour,
+				UntrackedAfter: 30 * time.Minute,
+			}}
+			rt := newReminderTest(t, c.idleSince, svc)
+			defer rt.reminders.Stop()
+
+			svc.since = midnight.Add(c.idleSince)
+
+			rt.start()
+			advance(t, rt.clock, midnight.Add(c.now).Sub(rt.clock.Now())-time.Minute)
+			rt.assertReminders()
+
+			advance(t, rt.clock, time.Minute)
+			rt.assertReminders(Reminder{Kind: UntrackedReminder, Activity: idleActivity, Since: midnight.Add(c.since), At: midnight.Add(c.now)})
+
+			// the warning is sent once per idle period.
+			advance(t, rt.clock, time.Hour)
+			rt.assertReminders()
+		})
+	}
+}
+
+func TestRemindersUntrackedOnWeekends(t *testing.T) {
+	svc := &flipSvc{current: idleActivity, rules: ReminderRules{
+		HasWorkHours:   true,
+		WorkStart:      9 * time.Hour,
+		WorkEnd:        18 * time.Hour,
+		UntrackedAfter: 30 * time.Minute,
+	}}
+	rt := newReminderTest(t, 9*time.Hour, svc)
+	defer rt.reminders.Stop()
+
+	rt.clock.Set(rt.clock.Now().AddDate(0, 0, 5))
+	svc.since = rt.clock.Now()
+
+	rt.start()
+	advance(t, rt.clock, time.Hour)
+	rt.assertReminders()
+}
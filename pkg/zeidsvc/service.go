@@ -2,11 +2,14 @@ package zeidsvc
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"github.com/pauldub/zei/pkg/clock"
 	"github.com/pauldub/zei/pkg/zei"
 	"github.com/pauldub/zei/rpc/zeid"
 	"github.com/go-ble/ble"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
 )
 
@@ -27,6 +30,8 @@ const (
 type ZeiSvc interface {
 	zeid.Zei
 
+	Serial() string
+
 	Current() zei.Activity
 	StartTime() time.Time
 
@@ -37,27 +42,75 @@ type ZeiSvc interface {
 
 	Start(ctx context.Context, new zei.Activity) error
 	Stop(ctx context.Context) error
+	Switch(ctx context.Context, new zei.Activity, at time.Time) error
 	IsIdle() bool
+
+	DeviceConnected() bool
+	Info() DeviceInfo
+	BatteryLevel() (int, error)
+	RecordOrientation(at time.Time)
+	SetQueueDepth(depth func() int)
+
+	SetIdleGap(gap IdleGap)
+
+	ReminderRules() ReminderRules
+	SetReminderRules(rules ReminderRules)
+
+	FocusSession() *FocusSession
+	SetFocusSession(session *FocusSession)
+
+	Profile() string
+	SetProfiles(profiles []Profile, onSwitch func(name string))
+	UseProfile(ctx context.Context, name string) error
 }
 
 type zeisvc struct {
-	api           *zei.Client
-	token         string
-	current       zei.Activity
-	startTime     time.Time
+	serial        string
+	clock         clock.Clock
 	activitiesMap map[int]zei.Activity
+	observe       zei.RequestObserver
+
+	// switchMu serializes the changes of the tracked activity, mu is not
+	// held while the API is called so readers are not blocked meanwhile.
+	switchMu sync.Mutex
+	// profileMu serializes the changes of the active profile.
+	profileMu sync.Mutex
+
+	mu        sync.RWMutex
+	api       *zei.Client
+	token     string
+	account   account
+	fallback  account
+	profiles  []Profile
+	profile   string
+	onSwitch  func(name string)
+	current   zei.Activity
+	startTime time.Time
+	idleGap   *IdleGap
+	rules     ReminderRules
+	focus     *FocusSession
+
+	lastOrientation time.Time
+	queueDepth      func() int
 
 	bleConn     ble.Client
 	orientation *ble.Characteristic
+	battery     *ble.Characteristic
+	info        DeviceInfo
 }
 
 func NewService(
 	ctx context.Context,
+	serial string,
 	apiKey, apiSecret string,
 	conn ble.Client,
 	profile *ble.Profile,
+	clk clock.Clock,
+	observe zei.RequestObserver,
 ) (ZeiSvc, error) {
+	clk = clock.OrReal(clk)
 	apiClient := zei.NewClient()
+	apiClient.SetObserver(observe)
 
 	accessToken, err := apiClient.DeveloperSignIn(ctx, apiKey, apiSecret)
 	if err != nil {
@@ -103,13 +156,13 @@ func NewService(
 	}
 
 	if currentTracking.Activity.ID != currentActivity.ID {
-		err = apiClient.StopTracking(ctx, accessToken, currentTracking.Activity.ID, time.Now())
+		err = apiClient.StopTracking(ctx, accessToken, currentTracking.Activity.ID, clk.Now())
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to stop current tracking activity")
 		}
 
 		if currentActivity.Name != "Idle" {
-			err = apiClient.StartTracking(ctx, accessToken, currentTracking.Activity.ID, time.Now())
+			err = apiClient.StartTracking(ctx, accessToken, currentTracking.Activity.ID, clk.Now())
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to start current activity")
 			}
@@ -121,7 +174,7 @@ func NewService(
 	for _, a := range activities {
 		if a.ID == currentTracking.Activity.ID {
 			currentActivity = a
-			startTime, err = time.Parse(zei.TimeFormat, currentTracking.StartedAt)
+			startTime, err = currentTracking.StartTime()
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to parse tracking start time")
 			}
@@ -130,39 +183,68 @@ func NewService(
 	}
 
 	return &zeisvc{
+		serial:        serial,
 		api:           apiClient,
+		observe:       observe,
 		token:         accessToken,
+		account:       account{zei.DefaultBaseURL, apiKey, apiSecret},
+		fallback:      account{zei.DefaultBaseURL, apiKey, apiSecret},
+		profile:       DefaultProfile,
+		clock:         clk,
 		activitiesMap: activitiesMap,
 		current:       currentActivity,
 		startTime:     startTime,
 		bleConn:       conn,
 		orientation:   orientation,
+		battery:       profile.FindCharacteristic(ble.NewCharacteristic(batteryLevelCharacteristic)),
+		info:          readDeviceInfo(conn, profile),
 	}, nil
 }
 
 func (z *zeisvc) CurrentActivity(ctx context.Context, req *zeid.CurrentActivityReq) (*zeid.CurrentActivityResp, error) {
-	return &zeid.CurrentActivityResp{
+	current, startTime := z.Current(), z.StartTime()
+
+	res := &zeid.CurrentActivityResp{
 		Activity: &zeid.Activity{
-			Id:          z.current.ID,
-			Name:        z.current.Name,
-			Color:       z.current.Color,
-			Integration: z.current.Integration,
-			DeviceSide:  int64(z.current.DeviceSide),
+			Id:          current.ID,
+			Name:        current.Name,
+			Color:       current.Color,
+			Integration: current.Integration,
+			DeviceSide:  int64(current.DeviceSide),
 		},
-		StartTime: z.startTime.Format(time.RFC3339),
-		IsIdle:    z.IsIdle(),
-	}, nil
+		IsIdle:          current.Name == idleActivity.Name,
+		DeviceConnected: z.DeviceConnected(),
+		Device:          z.serial,
+	}
+
+	if !startTime.IsZero() {
+		start, err := ptypes.TimestampProto(startTime)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert start time")
+		}
+
+		res.StartTime = start
+		res.Duration = ptypes.DurationProto(z.clock.Since(startTime))
+	}
+
+	focus, err := focusSessionProto(z.FocusSession(), z.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	res.Focus = focus
+
+	return res, nil
 }
 
 func (z *zeisvc) ListActivities(ctx context.Context, req *zeid.ListActivitiesReq) (*zeid.ListActivitiesResp, error) {
-	activities, err := z.api.Activities(ctx, z.token)
+	activities, err := z.client().Activities(ctx, z.accessToken())
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to query ZEI activities")
 	}
 
 	var res = &zeid.ListActivitiesResp{
 		Activities:        make([]*zeid.Activity, 0, len(activities)),
-		CurrentActivityId: z.current.ID,
+		CurrentActivityId: z.Current().ID,
 	}
 
 	for _, a := range activities {
@@ -179,16 +261,44 @@ func (z *zeisvc) ListActivities(ctx context.Context, req *zeid.ListActivitiesReq
 }
 
 func (z *zeisvc) AssignActivity(ctx context.Context, req *zeid.AssignActivityReq) (*zeid.AssignActivityResp, error) {
-	currentSide, err := z.GetCurrentSide()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to read current Timeular side")
+	side := int(req.Side)
+	if side == 0 {
+		currentSide, err := z.GetCurrentSide()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read current Timeular side")
+		}
+		if currentSide == 0 {
+			return nil, failedPreconditionError("the device is not lying on a side")
+		}
+
+		side = currentSide
 	}
 
-	activity, err := z.api.AssignActivity(ctx, z.token, req.ActivityId, currentSide)
+	if side < minSide || side > maxSide {
+		return nil, invalidArgumentError("side", "side must be between %d and %d", minSide, maxSide)
+	}
+
+	activityID := req.ActivityId
+	if activityID == "" {
+		activities, err := z.client().Activities(ctx, z.accessToken())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query ZEI activities")
+		}
+
+		a, err := findActivity(activities, "", req.ActivityName)
+		if err != nil {
+			return nil, err
+		}
+
+		activityID = a.ID
+	}
+
+	activity, err := z.client().AssignActivity(ctx, z.accessToken(), activityID, side)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to assign activity")
 	}
 
+	z.mu.Lock()
 	// maintain activitiesMap state consistent
 	for key, a := range z.activitiesMap {
 		if a.ID == activity.ID {
@@ -196,47 +306,441 @@ func (z *zeisvc) AssignActivity(ctx context.Context, req *zeid.AssignActivityReq
 		}
 	}
 
-	z.activitiesMap[currentSide] = *activity
+	z.activitiesMap[side] = *activity
+	z.mu.Unlock()
+
+	return &zeid.AssignActivityResp{
+		Activity: &zeid.Activity{
+			Id:          activity.ID,
+			Name:        activity.Name,
+			Color:       activity.Color,
+			Integration: activity.Integration,
+			DeviceSide:  int64(side),
+		},
+		Side: int64(side),
+	}, nil
+}
+
+func (z *zeisvc) ListSides(ctx context.Context, req *zeid.ListSidesReq) (*zeid.ListSidesResp, error) {
+	currentSide, err := z.GetCurrentSide()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read current Timeular side")
+	}
+
+	res := &zeid.ListSidesResp{
+		Sides:       make([]*zeid.Side, 0, maxSide-minSide+1),
+		CurrentSide: int64(currentSide),
+	}
+
+	for side := minSide; side <= maxSide; side++ {
+		s := &zeid.Side{Number: int64(side)}
+
+		if a, ok := z.GetActivity(side); ok {
+			s.Activity = &zeid.Activity{
+				Id:          a.ID,
+				Name:        a.Name,
+				Color:       a.Color,
+				Integration: a.Integration,
+				DeviceSide:  int64(side),
+			}
+		}
+
+		res.Sides = append(res.Sides, s)
+	}
+
+	return res, nil
+}
+
+// ApplyLayout reconciles the sides of the device with the requested layout,
+// the changes are only planned on dry runs.
+func (z *zeisvc) ApplyLayout(ctx context.Context, req *zeid.ApplyLayoutReq) (*zeid.ApplyLayoutResp, error) {
+	changes, err := z.applyLayout(ctx, req.Sides, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &zeid.ApplyLayoutResp{
+		Changes: make([]*zeid.LayoutChange, 0, len(changes)),
+		Applied: !req.DryRun,
+	}
+
+	for _, change := range changes {
+		res.Changes = append(res.Changes, layoutChangeProto(change))
+	}
+
+	return res, nil
+}
+
+func (z *zeisvc) StartActivity(ctx context.Context, req *zeid.StartActivityReq) (*zeid.StartActivityResp, error) {
+	activities, err := z.client().Activities(ctx, z.accessToken())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query ZEI activities")
+	}
+
+	activity, err := findActivity(activities, req.ActivityId, req.ActivityName)
+	if err != nil {
+		return nil, err
+	}
+
+	if z.Current().ID != activity.ID {
+		err = z.Switch(ctx, *activity, z.clock.Now())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start activity")
+		}
+	}
+
+	return &zeid.StartActivityResp{
+		Activity: &zeid.Activity{
+			Id:          activity.ID,
+			Name:        activity.Name,
+			Color:       activity.Color,
+			Integration: activity.Integration,
+			DeviceSide:  int64(activity.DeviceSide),
+		},
+	}, nil
+}
+
+func (z *zeisvc) StopActivity(ctx context.Context, req *zeid.StopActivityReq) (*zeid.StopActivityResp, error) {
+	current := z.Current()
+
+	if current.Name != idleActivity.Name {
+		err := z.Switch(ctx, idleActivity, z.clock.Now())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to stop activity")
+		}
+	}
+
+	return &zeid.StopActivityResp{
+		Activity: &zeid.Activity{
+			Id:          current.ID,
+			Name:        current.Name,
+			Color:       current.Color,
+			Integration: current.Integration,
+			DeviceSide:  int64(current.DeviceSide),
+		},
+	}, nil
+}
+
+// History lists the entries between the requested times, from the start of
+// the day to now by default.
+func (z *zeisvc) History(ctx context.Context, req *zeid.HistoryReq) (*zeid.HistoryResp, error) {
+	var (
+		now      = z.clock.Now()
+		from, to = atTimeOfDay(now, 0), now
+		err      error
+	)
+
+	if req.From != nil {
+		from, err = ptypes.Timestamp(req.From)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid history start")
+		}
+	}
+
+	if req.To != nil {
+		to, err = ptypes.Timestamp(req.To)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid history end")
+		}
+	}
+
+	if !to.After(from) {
+		return nil, invalidArgumentError("to", "history must end after it starts")
+	}
+
+	timeEntries, err := z.client().TimeEntries(ctx, z.accessToken(), from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query ZEI time entries")
+	}
+
+	entries, err := clipEntries(timeEntries, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	current, startTime := z.Current(), z.StartTime()
+	if current.Name != idleActivity.Name && startTime.Before(to) {
+		running := entry{
+			activity:  current,
+			startedAt: startTime,
+		}
+		if running.startedAt.Before(from) {
+			running.startedAt = from
+		}
+		if to.Before(now) {
+			running.stoppedAt = to
+		}
+
+		entries = append([]entry{running}, entries...)
+	}
+
+	return historyProto(entries, now)
+}
+
+func (z *zeisvc) ResolveIdleGap(ctx context.Context, req *zeid.ResolveIdleGapReq) (*zeid.ResolveIdleGapResp, error) {
+	z.mu.Lock()
+	gap := z.idleGap
+	z.idleGap = nil
+	z.mu.Unlock()
+
+	if gap == nil {
+		return nil, failedPreconditionError("no idle gap to resolve")
+	}
+
+	if req.Keep {
+		err := z.client().CreateTimeEntry(ctx, z.accessToken(), gap.Activity.ID, gap.From, gap.To)
+		if err != nil {
+			z.SetIdleGap(*gap)
+			return nil, errors.Wrap(err, "failed to track idle gap")
+		}
+	}
+
+	from, err := ptypes.TimestampProto(gap.From)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert idle gap start")
+	}
+
+	to, err := ptypes.TimestampProto(gap.To)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert idle gap end")
+	}
+
+	return &zeid.ResolveIdleGapResp{
+		Activity: &zeid.Activity{
+			Id:          gap.Activity.ID,
+			Name:        gap.Activity.Name,
+			Color:       gap.Activity.Color,
+			Integration: gap.Activity.Integration,
+			DeviceSide:  int64(gap.Activity.DeviceSide),
+		},
+		From: from,
+		To:   to,
+		Kept: req.Keep,
+	}, nil
+}
+
+// SetIdleGap records the last idle gap, until it is resolved.
+func (z *zeisvc) SetIdleGap(gap IdleGap) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	z.idleGap = &gap
+}
+
+func (z *zeisvc) GetReminderRules(ctx context.Context, req *zeid.GetReminderRulesReq) (*zeid.GetReminderRulesResp, error) {
+	return &zeid.GetReminderRulesResp{
+		Rules: reminderRulesProto(z.ReminderRules()),
+	}, nil
+}
+
+func (z *zeisvc) UpdateReminderRules(ctx context.Context, req *zeid.UpdateReminderRulesReq) (*zeid.UpdateReminderRulesResp, error) {
+	rules, err := reminderRulesFromProto(req.Rules)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid reminder rules")
+	}
+
+	z.SetReminderRules(rules)
+
+	return &zeid.UpdateReminderRulesResp{
+		Rules: reminderRulesProto(rules),
+	}, nil
+}
+
+func (z *zeisvc) ReminderRules() ReminderRules {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	return z.rules
+}
+
+func (z *zeisvc) SetReminderRules(rules ReminderRules) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	z.rules = rules
+}
+
+// FocusSession returns the running focus session, nil when there is none.
+func (z *zeisvc) FocusSession() *FocusSession {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	return z.focus
+}
+
+// SetFocusSession records the running focus session, nil once it ended.
+func (z *zeisvc) SetFocusSession(session *FocusSession) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
 
-	return &zeid.AssignActivityResp{}, nil
+	z.focus = session
 }
 
 func (z *zeisvc) Start(ctx context.Context, new zei.Activity) error {
-	z.startTime = time.Now()
-	z.current = new
-	return z.api.StartTracking(ctx, z.token, new.ID, z.startTime)
+	z.switchMu.Lock()
+	defer z.switchMu.Unlock()
+
+	at := z.clock.Now().UTC()
+
+	err := z.client().StartTracking(ctx, z.accessToken(), new.ID, at)
+	if err != nil {
+		return err
+	}
+
+	z.setCurrent(new, at)
+
+	return nil
 }
 
 func (z *zeisvc) Stop(ctx context.Context) error {
-	return z.api.StopTracking(ctx, z.token, z.current.ID, time.Now())
+	return z.client().StopTracking(ctx, z.accessToken(), z.Current().ID, z.clock.Now())
+}
+
+// Switch stops tracking of the current activity and starts tracking the new
+// one, both at the given time. Switching to the idle activity only stops
+// tracking. The service is idle when the new activity could not be started.
+func (z *zeisvc) Switch(ctx context.Context, new zei.Activity, at time.Time) error {
+	z.switchMu.Lock()
+	defer z.switchMu.Unlock()
+
+	return z.switchLocked(ctx, new, at)
+}
+
+// switchLocked is Switch, z.switchMu must be held.
+func (z *zeisvc) switchLocked(ctx context.Context, new zei.Activity, at time.Time) error {
+	client, token := z.client(), z.accessToken()
+
+	if current := z.Current(); current.Name != idleActivity.Name {
+		err := client.StopTracking(ctx, token, current.ID, at)
+		if err != nil {
+			return errors.Wrap(err, "failed to stop tracking of current activity")
+		}
+	}
+
+	if new.Name == idleActivity.Name {
+		z.setCurrent(idleActivity, at)
+		return nil
+	}
+
+	err := client.StartTracking(ctx, token, new.ID, at)
+	if err != nil {
+		z.setCurrent(idleActivity, at)
+		return errors.Wrap(err, "failed to start tracking of new activity")
+	}
+
+	z.setCurrent(new, at)
+
+	return nil
+}
+
+// setCurrent records the tracked activity and when it started.
+func (z *zeisvc) setCurrent(a zei.Activity, at time.Time) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	z.current = a
+	z.startTime = at.UTC()
 }
 
 func (z *zeisvc) IsIdle() bool {
-	return z.current.Name == "Idle"
+	return z.Current().Name == idleActivity.Name
 }
 
 func (z *zeisvc) Current() zei.Activity {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
 	return z.current
 }
 
 func (z *zeisvc) StartTime() time.Time {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
 	return z.startTime
 }
 
+// reloadActivities refreshes the activities of each side from the API.
+func (z *zeisvc) reloadActivities(ctx context.Context) error {
+	activities, err := z.client().Activities(ctx, z.accessToken())
+	if err != nil {
+		return errors.Wrap(err, "failed to query ZEI activities")
+	}
+
+	activitiesMap := map[int]zei.Activity{}
+	for _, a := range activities {
+		activitiesMap[a.DeviceSide] = a
+	}
+	activitiesMap[0] = idleActivity
+
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	z.activitiesMap = activitiesMap
+
+	return nil
+}
+
 func (z *zeisvc) GetActivity(side int) (zei.Activity, bool) {
 	if side == 0 {
 		return idleActivity, true
 	}
 
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
 	a, ok := z.activitiesMap[side]
 	return a, ok
 }
 
 func (z *zeisvc) SetActivity(a zei.Activity) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
 	z.current = a
 }
 
+// ListDevices returns the device of the service.
+func (z *zeisvc) ListDevices(ctx context.Context, req *zeid.ListDevicesReq) (*zeid.ListDevicesResp, error) {
+	current := z.Current()
+
+	return &zeid.ListDevicesResp{
+		Devices: []*zeid.Device{{
+			Serial:    z.serial,
+			Connected: z.DeviceConnected(),
+			Profile:   z.Profile(),
+			Activity: &zeid.Activity{
+				Id:          current.ID,
+				Name:        current.Name,
+				Color:       current.Color,
+				Integration: current.Integration,
+				DeviceSide:  int64(current.DeviceSide),
+			},
+			IsIdle: current.Name == idleActivity.Name,
+		}},
+	}, nil
+}
+
+// Serial returns the serial number of the device.
+func (z *zeisvc) Serial() string {
+	return z.serial
+}
+
+// DeviceConnected returns whether the device is still connected.
+func (z *zeisvc) DeviceConnected() bool {
+	select {
+	case <-z.bleConn.Disconnected():
+		return false
+	default:
+		return true
+	}
+}
+
 func (z *zeisvc) GetCurrentSide() (int, error) {
+	if !z.DeviceConnected() {
+		return 0, ErrDeviceDisconnected
+	}
+
 	currentSide, err := z.bleConn.ReadCharacteristic(z.orientation)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to read device orientation")
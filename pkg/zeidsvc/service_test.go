@@ -0,0 +1,102 @@
+package zeidsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pauldub/zei/pkg/clock"
+	"github.com/pauldub/zei/rpc/zeid"
+	"github.com/go-ble/ble"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// connectedClient is a BLE connection which stays connected.
+type connectedClient struct {
+	ble.Client
+}
+
+func (connectedClient) Disconnected() <-chan struct{} {
+	return make(chan struct{})
+}
+
+func TestCurrentActivityDST(t *testing.T) {
+	cases := []struct {
+		name    string
+		zone    string
+		start   time.Time
+		now     time.Time
+		elapsed time.Duration
+	}{
+		{
+			name:    "spring forward",
+			zone:    "Europe/Paris",
+			start:   time.Date(2019, time.March, 31, 1, 30, 0, 0, time.UTC),
+			now:     time.Date(2019, time.March, 31, 3, 30, 0, 0, time.UTC),
+			elapsed: time.Hour,
+		},
+		{
+			name:    "fall back",
+			zone:    "Europe/Paris",
+			start:   time.Date(2019, time.October, 27, 1, 30, 0, 0, time.UTC),
+			now:     time.Date(2019, time.October, 27, 3, 30, 0, 0, time.UTC),
+			elapsed: 3 * time.Hour,
+		},
+		{
+			name:    "spring forward west of UTC",
+			zone:    "America/New_York",
+			start:   time.Date(2019, time.March, 9, 23, 0, 0, 0, time.UTC),
+			now:     time.Date(2019, time.March, 10, 9, 0, 0, 0, time.UTC),
+			elapsed: 9 * time.Hour,
+		},
+		{
+			name:    "fall back west of UTC",
+			zone:    "America/New_York",
+			start:   time.Date(2019, time.November, 2, 23, 0, 0, 0, time.UTC),
+			now:     time.Date(2019, time.November, 3, 9, 0, 0, 0, time.UTC),
+			elapsed: 11 * time.Hour,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			location, err := time.LoadLocation(c.zone)
+			if err != nil {
+				t.Skipf("time zone %s is not available: %v", c.zone, err)
+			}
+
+			// the wall clocks of the case, in the time zone.
+			wall := func(t time.Time) time.Time {
+				return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, location)
+			}
+			start, now := wall(c.start), wall(c.now)
+
+			svc := &zeisvc{
+				clock:   clock.NewFake(now),
+				bleConn: connectedClient{},
+			}
+			svc.setCurrent(coding, start)
+
+			res, err := svc.CurrentActivity(context.Background(), &zeid.CurrentActivityReq{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			startTime, err := ptypes.Timestamp(res.StartTime)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !startTime.Equal(start) {
+				t.Errorf("got start time %s, want %s", startTime, start.UTC())
+			}
+
+			duration, err := ptypes.Duration(res.Duration)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if duration != c.elapsed {
+				t.Errorf("got duration %s, want %s", duration, c.elapsed)
+			}
+		})
+	}
+}
@@ -13,14 +13,52 @@ It has these top-level messages:
 	ListActivitiesResp
 	CurrentActivityReq
 	CurrentActivityResp
+	FocusSession
 	AssignActivityReq
 	AssignActivityResp
+	Side
+	ListSidesReq
+	ListSidesResp
+	StartActivityReq
+	StartActivityResp
+	StopActivityReq
+	StopActivityResp
+	TimeEntry
+	ActivityTotal
+	HistoryReq
+	HistoryResp
+	LayoutSide
+	LayoutChange
+	ApplyLayoutReq
+	ApplyLayoutResp
+	ResolveIdleGapReq
+	ResolveIdleGapResp
+	ReminderRules
+	GetReminderRulesReq
+	GetReminderRulesResp
+	UpdateReminderRulesReq
+	UpdateReminderRulesResp
+	Profile
+	ListProfilesReq
+	ListProfilesResp
+	SwitchProfileReq
+	SwitchProfileResp
+	Device
+	ListDevicesReq
+	ListDevicesResp
+	DeviceInfoReq
+	DeviceInfoResp
+	HealthReq
+	DeviceHealth
+	HealthResp
 */
 package zeid
 
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import google_protobuf "github.com/golang/protobuf/ptypes/duration"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/timestamp"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -33,6 +71,52 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// FocusPhase is a phase of a focus session cycle.
+type FocusPhase int32
+
+const (
+	FocusPhase_FOCUS_WORK  FocusPhase = 0
+	FocusPhase_FOCUS_BREAK FocusPhase = 1
+)
+
+var FocusPhase_name = map[int32]string{
+	0: "FOCUS_WORK",
+	1: "FOCUS_BREAK",
+}
+var FocusPhase_value = map[string]int32{
+	"FOCUS_WORK":  0,
+	"FOCUS_BREAK": 1,
+}
+
+func (x FocusPhase) String() string {
+	return proto.EnumName(FocusPhase_name, int32(x))
+}
+func (FocusPhase) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+type LayoutChangeKind int32
+
+const (
+	LayoutChangeKind_LAYOUT_ASSIGN   LayoutChangeKind = 0
+	LayoutChangeKind_LAYOUT_CREATE   LayoutChangeKind = 1
+	LayoutChangeKind_LAYOUT_UNASSIGN LayoutChangeKind = 2
+)
+
+var LayoutChangeKind_name = map[int32]string{
+	0: "LAYOUT_ASSIGN",
+	1: "LAYOUT_CREATE",
+	2: "LAYOUT_UNASSIGN",
+}
+var LayoutChangeKind_value = map[string]int32{
+	"LAYOUT_ASSIGN":   0,
+	"LAYOUT_CREATE":   1,
+	"LAYOUT_UNASSIGN": 2,
+}
+
+func (x LayoutChangeKind) String() string {
+	return proto.EnumName(LayoutChangeKind_name, int32(x))
+}
+func (LayoutChangeKind) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
 type Activity struct {
 	Id          string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	Name        string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
@@ -82,6 +166,7 @@ func (m *Activity) GetDeviceSide() int64 {
 }
 
 type ListActivitiesReq struct {
+	Device string `protobuf:"bytes,1,opt,name=device" json:"device,omitempty"`
 }
 
 func (m *ListActivitiesReq) Reset()                    { *m = ListActivitiesReq{} }
@@ -89,6 +174,13 @@ func (m *ListActivitiesReq) String() string            { return proto.CompactTex
 func (*ListActivitiesReq) ProtoMessage()               {}
 func (*ListActivitiesReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
+func (m *ListActivitiesReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
 type ListActivitiesResp struct {
 	Activities        []*Activity `protobuf:"bytes,1,rep,name=activities" json:"activities,omitempty"`
 	CurrentActivityId string      `protobuf:"bytes,2,opt,name=current_activity_id,json=currentActivityId" json:"current_activity_id,omitempty"`
@@ -114,6 +206,7 @@ func (m *ListActivitiesResp) GetCurrentActivityId() string {
 }
 
 type CurrentActivityReq struct {
+	Device string `protobuf:"bytes,1,opt,name=device" json:"device,omitempty"`
 }
 
 func (m *CurrentActivityReq) Reset()                    { *m = CurrentActivityReq{} }
@@ -121,10 +214,21 @@ func (m *CurrentActivityReq) String() string            { return proto.CompactTe
 func (*CurrentActivityReq) ProtoMessage()               {}
 func (*CurrentActivityReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
+func (m *CurrentActivityReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
 type CurrentActivityResp struct {
-	Activity  *Activity `protobuf:"bytes,1,opt,name=activity" json:"activity,omitempty"`
-	StartTime string    `protobuf:"bytes,2,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
-	IsIdle    bool      `protobuf:"varint,3,opt,name=is_idle,json=isIdle" json:"is_idle,omitempty"`
+	Activity        *Activity                   `protobuf:"bytes,1,opt,name=activity" json:"activity,omitempty"`
+	IsIdle          bool                        `protobuf:"varint,3,opt,name=is_idle,json=isIdle" json:"is_idle,omitempty"`
+	StartTime       *google_protobuf1.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+	Duration        *google_protobuf.Duration   `protobuf:"bytes,5,opt,name=duration" json:"duration,omitempty"`
+	Focus           *FocusSession               `protobuf:"bytes,6,opt,name=focus" json:"focus,omitempty"`
+	DeviceConnected bool                        `protobuf:"varint,7,opt,name=device_connected,json=deviceConnected" json:"device_connected,omitempty"`
+	Device          string                      `protobuf:"bytes,8,opt,name=device" json:"device,omitempty"`
 }
 
 func (m *CurrentActivityResp) Reset()                    { *m = CurrentActivityResp{} }
@@ -139,28 +243,120 @@ func (m *CurrentActivityResp) GetActivity() *Activity {
 	return nil
 }
 
-func (m *CurrentActivityResp) GetStartTime() string {
+func (m *CurrentActivityResp) GetIsIdle() bool {
+	if m != nil {
+		return m.IsIdle
+	}
+	return false
+}
+
+func (m *CurrentActivityResp) GetStartTime() *google_protobuf1.Timestamp {
 	if m != nil {
 		return m.StartTime
 	}
-	return ""
+	return nil
 }
 
-func (m *CurrentActivityResp) GetIsIdle() bool {
+func (m *CurrentActivityResp) GetDuration() *google_protobuf.Duration {
 	if m != nil {
-		return m.IsIdle
+		return m.Duration
+	}
+	return nil
+}
+
+func (m *CurrentActivityResp) GetFocus() *FocusSession {
+	if m != nil {
+		return m.Focus
+	}
+	return nil
+}
+
+func (m *CurrentActivityResp) GetDeviceConnected() bool {
+	if m != nil {
+		return m.DeviceConnected
 	}
 	return false
 }
 
+func (m *CurrentActivityResp) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+// FocusSession is a running focus session, made of cycles of work and break
+// phases.
+type FocusSession struct {
+	Activity  *Activity                   `protobuf:"bytes,1,opt,name=activity" json:"activity,omitempty"`
+	Phase     FocusPhase                  `protobuf:"varint,2,opt,name=phase,enum=zei.zeid.FocusPhase" json:"phase,omitempty"`
+	Cycle     int64                       `protobuf:"varint,3,opt,name=cycle" json:"cycle,omitempty"`
+	Cycles    int64                       `protobuf:"varint,4,opt,name=cycles" json:"cycles,omitempty"`
+	EndsAt    *google_protobuf1.Timestamp `protobuf:"bytes,5,opt,name=ends_at,json=endsAt" json:"ends_at,omitempty"`
+	Remaining *google_protobuf.Duration   `protobuf:"bytes,6,opt,name=remaining" json:"remaining,omitempty"`
+}
+
+func (m *FocusSession) Reset()                    { *m = FocusSession{} }
+func (m *FocusSession) String() string            { return proto.CompactTextString(m) }
+func (*FocusSession) ProtoMessage()               {}
+func (*FocusSession) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *FocusSession) GetActivity() *Activity {
+	if m != nil {
+		return m.Activity
+	}
+	return nil
+}
+
+func (m *FocusSession) GetPhase() FocusPhase {
+	if m != nil {
+		return m.Phase
+	}
+	return FocusPhase_FOCUS_WORK
+}
+
+func (m *FocusSession) GetCycle() int64 {
+	if m != nil {
+		return m.Cycle
+	}
+	return 0
+}
+
+func (m *FocusSession) GetCycles() int64 {
+	if m != nil {
+		return m.Cycles
+	}
+	return 0
+}
+
+func (m *FocusSession) GetEndsAt() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.EndsAt
+	}
+	return nil
+}
+
+func (m *FocusSession) GetRemaining() *google_protobuf.Duration {
+	if m != nil {
+		return m.Remaining
+	}
+	return nil
+}
+
+// AssignActivityReq designates the activity by ID, or else by name, names
+// match exactly or else by unambiguous prefix or substring. The activity is
+// assigned to the side on top of the device when side is zero.
 type AssignActivityReq struct {
-	ActivityId string `protobuf:"bytes,1,opt,name=activity_id,json=activityId" json:"activity_id,omitempty"`
+	ActivityId   string `protobuf:"bytes,1,opt,name=activity_id,json=activityId" json:"activity_id,omitempty"`
+	Side         int64  `protobuf:"varint,2,opt,name=side" json:"side,omitempty"`
+	ActivityName string `protobuf:"bytes,3,opt,name=activity_name,json=activityName" json:"activity_name,omitempty"`
+	Device       string `protobuf:"bytes,4,opt,name=device" json:"device,omitempty"`
 }
 
 func (m *AssignActivityReq) Reset()                    { *m = AssignActivityReq{} }
 func (m *AssignActivityReq) String() string            { return proto.CompactTextString(m) }
 func (*AssignActivityReq) ProtoMessage()               {}
-func (*AssignActivityReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*AssignActivityReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *AssignActivityReq) GetActivityId() string {
 	if m != nil {
@@ -169,13 +365,1096 @@ func (m *AssignActivityReq) GetActivityId() string {
 	return ""
 }
 
+func (m *AssignActivityReq) GetSide() int64 {
+	if m != nil {
+		return m.Side
+	}
+	return 0
+}
+
+func (m *AssignActivityReq) GetActivityName() string {
+	if m != nil {
+		return m.ActivityName
+	}
+	return ""
+}
+
+func (m *AssignActivityReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
 type AssignActivityResp struct {
+	Activity *Activity `protobuf:"bytes,1,opt,name=activity" json:"activity,omitempty"`
+	Side     int64     `protobuf:"varint,2,opt,name=side" json:"side,omitempty"`
 }
 
 func (m *AssignActivityResp) Reset()                    { *m = AssignActivityResp{} }
 func (m *AssignActivityResp) String() string            { return proto.CompactTextString(m) }
 func (*AssignActivityResp) ProtoMessage()               {}
-func (*AssignActivityResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*AssignActivityResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *AssignActivityResp) GetActivity() *Activity {
+	if m != nil {
+		return m.Activity
+	}
+	return nil
+}
+
+func (m *AssignActivityResp) GetSide() int64 {
+	if m != nil {
+		return m.Side
+	}
+	return 0
+}
+
+// Side is a side of the device, activity is unset when no activity is
+// assigned to it.
+type Side struct {
+	Number   int64     `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+	Activity *Activity `protobuf:"bytes,2,opt,name=activity" json:"activity,omitempty"`
+}
+
+func (m *Side) Reset()                    { *m = Side{} }
+func (m *Side) String() string            { return proto.CompactTextString(m) }
+func (*Side) ProtoMessage()               {}
+func (*Side) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *Side) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *Side) GetActivity() *Activity {
+	if m != nil {
+		return m.Activity
+	}
+	return nil
+}
+
+type ListSidesReq struct {
+	Device string `protobuf:"bytes,1,opt,name=device" json:"device,omitempty"`
+}
+
+func (m *ListSidesReq) Reset()                    { *m = ListSidesReq{} }
+func (m *ListSidesReq) String() string            { return proto.CompactTextString(m) }
+func (*ListSidesReq) ProtoMessage()               {}
+func (*ListSidesReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *ListSidesReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+// ListSidesResp lists every side of the device, current_side is zero when
+// the device is not lying on a side.
+type ListSidesResp struct {
+	Sides       []*Side `protobuf:"bytes,1,rep,name=sides" json:"sides,omitempty"`
+	CurrentSide int64   `protobuf:"varint,2,opt,name=current_side,json=currentSide" json:"current_side,omitempty"`
+}
+
+func (m *ListSidesResp) Reset()                    { *m = ListSidesResp{} }
+func (m *ListSidesResp) String() string            { return proto.CompactTextString(m) }
+func (*ListSidesResp) ProtoMessage()               {}
+func (*ListSidesResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *ListSidesResp) GetSides() []*Side {
+	if m != nil {
+		return m.Sides
+	}
+	return nil
+}
+
+func (m *ListSidesResp) GetCurrentSide() int64 {
+	if m != nil {
+		return m.CurrentSide
+	}
+	return 0
+}
+
+// StartActivityReq starts the activity with the given ID, or else the one
+// with the given name or an unambiguous part of it.
+type StartActivityReq struct {
+	ActivityId   string `protobuf:"bytes,1,opt,name=activity_id,json=activityId" json:"activity_id,omitempty"`
+	Device       string `protobuf:"bytes,2,opt,name=device" json:"device,omitempty"`
+	ActivityName string `protobuf:"bytes,3,opt,name=activity_name,json=activityName" json:"activity_name,omitempty"`
+}
+
+func (m *StartActivityReq) Reset()                    { *m = StartActivityReq{} }
+func (m *StartActivityReq) String() string            { return proto.CompactTextString(m) }
+func (*StartActivityReq) ProtoMessage()               {}
+func (*StartActivityReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *StartActivityReq) GetActivityId() string {
+	if m != nil {
+		return m.ActivityId
+	}
+	return ""
+}
+
+func (m *StartActivityReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *StartActivityReq) GetActivityName() string {
+	if m != nil {
+		return m.ActivityName
+	}
+	return ""
+}
+
+type StartActivityResp struct {
+	Activity *Activity `protobuf:"bytes,1,opt,name=activity" json:"activity,omitempty"`
+}
+
+func (m *StartActivityResp) Reset()                    { *m = StartActivityResp{} }
+func (m *StartActivityResp) String() string            { return proto.CompactTextString(m) }
+func (*StartActivityResp) ProtoMessage()               {}
+func (*StartActivityResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *StartActivityResp) GetActivity() *Activity {
+	if m != nil {
+		return m.Activity
+	}
+	return nil
+}
+
+type StopActivityReq struct {
+	Device string `protobuf:"bytes,1,opt,name=device" json:"device,omitempty"`
+}
+
+func (m *StopActivityReq) Reset()                    { *m = StopActivityReq{} }
+func (m *StopActivityReq) String() string            { return proto.CompactTextString(m) }
+func (*StopActivityReq) ProtoMessage()               {}
+func (*StopActivityReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *StopActivityReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+type StopActivityResp struct {
+	Activity *Activity `protobuf:"bytes,1,opt,name=activity" json:"activity,omitempty"`
+}
+
+func (m *StopActivityResp) Reset()                    { *m = StopActivityResp{} }
+func (m *StopActivityResp) String() string            { return proto.CompactTextString(m) }
+func (*StopActivityResp) ProtoMessage()               {}
+func (*StopActivityResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *StopActivityResp) GetActivity() *Activity {
+	if m != nil {
+		return m.Activity
+	}
+	return nil
+}
+
+// TimeEntry is the time tracked on an activity, the entry being tracked has
+// no stop time.
+type TimeEntry struct {
+	Id        string                      `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Activity  *Activity                   `protobuf:"bytes,2,opt,name=activity" json:"activity,omitempty"`
+	StartedAt *google_protobuf1.Timestamp `protobuf:"bytes,3,opt,name=started_at,json=startedAt" json:"started_at,omitempty"`
+	StoppedAt *google_protobuf1.Timestamp `protobuf:"bytes,4,opt,name=stopped_at,json=stoppedAt" json:"stopped_at,omitempty"`
+}
+
+func (m *TimeEntry) Reset()                    { *m = TimeEntry{} }
+func (m *TimeEntry) String() string            { return proto.CompactTextString(m) }
+func (*TimeEntry) ProtoMessage()               {}
+func (*TimeEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *TimeEntry) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *TimeEntry) GetActivity() *Activity {
+	if m != nil {
+		return m.Activity
+	}
+	return nil
+}
+
+func (m *TimeEntry) GetStartedAt() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *TimeEntry) GetStoppedAt() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.StoppedAt
+	}
+	return nil
+}
+
+type ActivityTotal struct {
+	Activity *Activity                 `protobuf:"bytes,1,opt,name=activity" json:"activity,omitempty"`
+	Duration *google_protobuf.Duration `protobuf:"bytes,2,opt,name=duration" json:"duration,omitempty"`
+}
+
+func (m *ActivityTotal) Reset()                    { *m = ActivityTotal{} }
+func (m *ActivityTotal) String() string            { return proto.CompactTextString(m) }
+func (*ActivityTotal) ProtoMessage()               {}
+func (*ActivityTotal) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *ActivityTotal) GetActivity() *Activity {
+	if m != nil {
+		return m.Activity
+	}
+	return nil
+}
+
+func (m *ActivityTotal) GetDuration() *google_protobuf.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+type HistoryReq struct {
+	From   *google_protobuf1.Timestamp `protobuf:"bytes,1,opt,name=from" json:"from,omitempty"`
+	To     *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=to" json:"to,omitempty"`
+	Device string                      `protobuf:"bytes,3,opt,name=device" json:"device,omitempty"`
+}
+
+func (m *HistoryReq) Reset()                    { *m = HistoryReq{} }
+func (m *HistoryReq) String() string            { return proto.CompactTextString(m) }
+func (*HistoryReq) ProtoMessage()               {}
+func (*HistoryReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *HistoryReq) GetFrom() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *HistoryReq) GetTo() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *HistoryReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+// HistoryResp lists the entries between two times, most recent first, along
+// with the time tracked on each activity, the longest first. Entries are
+// clipped to the requested interval.
+type HistoryResp struct {
+	Entries []*TimeEntry              `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	Totals  []*ActivityTotal          `protobuf:"bytes,2,rep,name=totals" json:"totals,omitempty"`
+	Total   *google_protobuf.Duration `protobuf:"bytes,3,opt,name=total" json:"total,omitempty"`
+}
+
+func (m *HistoryResp) Reset()                    { *m = HistoryResp{} }
+func (m *HistoryResp) String() string            { return proto.CompactTextString(m) }
+func (*HistoryResp) ProtoMessage()               {}
+func (*HistoryResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *HistoryResp) GetEntries() []*TimeEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *HistoryResp) GetTotals() []*ActivityTotal {
+	if m != nil {
+		return m.Totals
+	}
+	return nil
+}
+
+func (m *HistoryResp) GetTotal() *google_protobuf.Duration {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+// LayoutSide declares the activity of a side, activities are designated by
+// name and created with the given color when missing.
+type LayoutSide struct {
+	Number       int64  `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+	ActivityName string `protobuf:"bytes,2,opt,name=activity_name,json=activityName" json:"activity_name,omitempty"`
+	Color        string `protobuf:"bytes,3,opt,name=color" json:"color,omitempty"`
+}
+
+func (m *LayoutSide) Reset()                    { *m = LayoutSide{} }
+func (m *LayoutSide) String() string            { return proto.CompactTextString(m) }
+func (*LayoutSide) ProtoMessage()               {}
+func (*LayoutSide) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *LayoutSide) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *LayoutSide) GetActivityName() string {
+	if m != nil {
+		return m.ActivityName
+	}
+	return ""
+}
+
+func (m *LayoutSide) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
+
+// LayoutChange is a change of a side applied to reconcile the device with a
+// layout, previous is the activity the side had before.
+type LayoutChange struct {
+	Kind     LayoutChangeKind `protobuf:"varint,1,opt,name=kind,enum=zei.zeid.LayoutChangeKind" json:"kind,omitempty"`
+	Side     int64            `protobuf:"varint,2,opt,name=side" json:"side,omitempty"`
+	Activity *Activity        `protobuf:"bytes,3,opt,name=activity" json:"activity,omitempty"`
+	Previous *Activity        `protobuf:"bytes,4,opt,name=previous" json:"previous,omitempty"`
+}
+
+func (m *LayoutChange) Reset()                    { *m = LayoutChange{} }
+func (m *LayoutChange) String() string            { return proto.CompactTextString(m) }
+func (*LayoutChange) ProtoMessage()               {}
+func (*LayoutChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *LayoutChange) GetKind() LayoutChangeKind {
+	if m != nil {
+		return m.Kind
+	}
+	return LayoutChangeKind_LAYOUT_ASSIGN
+}
+
+func (m *LayoutChange) GetSide() int64 {
+	if m != nil {
+		return m.Side
+	}
+	return 0
+}
+
+func (m *LayoutChange) GetActivity() *Activity {
+	if m != nil {
+		return m.Activity
+	}
+	return nil
+}
+
+func (m *LayoutChange) GetPrevious() *Activity {
+	if m != nil {
+		return m.Previous
+	}
+	return nil
+}
+
+// ApplyLayoutReq declares the activities of all the sides of the device,
+// sides which are not declared are unassigned.
+type ApplyLayoutReq struct {
+	Sides  []*LayoutSide `protobuf:"bytes,1,rep,name=sides" json:"sides,omitempty"`
+	DryRun bool          `protobuf:"varint,2,opt,name=dry_run,json=dryRun" json:"dry_run,omitempty"`
+	Device string        `protobuf:"bytes,3,opt,name=device" json:"device,omitempty"`
+}
+
+func (m *ApplyLayoutReq) Reset()                    { *m = ApplyLayoutReq{} }
+func (m *ApplyLayoutReq) String() string            { return proto.CompactTextString(m) }
+func (*ApplyLayoutReq) ProtoMessage()               {}
+func (*ApplyLayoutReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *ApplyLayoutReq) GetSides() []*LayoutSide {
+	if m != nil {
+		return m.Sides
+	}
+	return nil
+}
+
+func (m *ApplyLayoutReq) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *ApplyLayoutReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+type ApplyLayoutResp struct {
+	Changes []*LayoutChange `protobuf:"bytes,1,rep,name=changes" json:"changes,omitempty"`
+	Applied bool            `protobuf:"varint,2,opt,name=applied" json:"applied,omitempty"`
+}
+
+func (m *ApplyLayoutResp) Reset()                    { *m = ApplyLayoutResp{} }
+func (m *ApplyLayoutResp) String() string            { return proto.CompactTextString(m) }
+func (*ApplyLayoutResp) ProtoMessage()               {}
+func (*ApplyLayoutResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *ApplyLayoutResp) GetChanges() []*LayoutChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *ApplyLayoutResp) GetApplied() bool {
+	if m != nil {
+		return m.Applied
+	}
+	return false
+}
+
+type ResolveIdleGapReq struct {
+	Keep   bool   `protobuf:"varint,1,opt,name=keep" json:"keep,omitempty"`
+	Device string `protobuf:"bytes,2,opt,name=device" json:"device,omitempty"`
+}
+
+func (m *ResolveIdleGapReq) Reset()                    { *m = ResolveIdleGapReq{} }
+func (m *ResolveIdleGapReq) String() string            { return proto.CompactTextString(m) }
+func (*ResolveIdleGapReq) ProtoMessage()               {}
+func (*ResolveIdleGapReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ResolveIdleGapReq) GetKeep() bool {
+	if m != nil {
+		return m.Keep
+	}
+	return false
+}
+
+func (m *ResolveIdleGapReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+type ResolveIdleGapResp struct {
+	Activity *Activity                   `protobuf:"bytes,1,opt,name=activity" json:"activity,omitempty"`
+	From     *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=from" json:"from,omitempty"`
+	To       *google_protobuf1.Timestamp `protobuf:"bytes,3,opt,name=to" json:"to,omitempty"`
+	Kept     bool                        `protobuf:"varint,4,opt,name=kept" json:"kept,omitempty"`
+}
+
+func (m *ResolveIdleGapResp) Reset()                    { *m = ResolveIdleGapResp{} }
+func (m *ResolveIdleGapResp) String() string            { return proto.CompactTextString(m) }
+func (*ResolveIdleGapResp) ProtoMessage()               {}
+func (*ResolveIdleGapResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ResolveIdleGapResp) GetActivity() *Activity {
+	if m != nil {
+		return m.Activity
+	}
+	return nil
+}
+
+func (m *ResolveIdleGapResp) GetFrom() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *ResolveIdleGapResp) GetTo() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *ResolveIdleGapResp) GetKept() bool {
+	if m != nil {
+		return m.Kept
+	}
+	return false
+}
+
+// ReminderRules are the rules reminding of forgotten tracking. Times of day
+// are durations since midnight, unset values disable a rule as do zero
+// durations.
+type ReminderRules struct {
+	LongTracking   *google_protobuf.Duration `protobuf:"bytes,1,opt,name=long_tracking,json=longTracking" json:"long_tracking,omitempty"`
+	EndOfDay       *google_protobuf.Duration `protobuf:"bytes,2,opt,name=end_of_day,json=endOfDay" json:"end_of_day,omitempty"`
+	WorkStart      *google_protobuf.Duration `protobuf:"bytes,3,opt,name=work_start,json=workStart" json:"work_start,omitempty"`
+	WorkEnd        *google_protobuf.Duration `protobuf:"bytes,4,opt,name=work_end,json=workEnd" json:"work_end,omitempty"`
+	UntrackedAfter *google_protobuf.Duration `protobuf:"bytes,5,opt,name=untracked_after,json=untrackedAfter" json:"untracked_after,omitempty"`
+}
+
+func (m *ReminderRules) Reset()                    { *m = ReminderRules{} }
+func (m *ReminderRules) String() string            { return proto.CompactTextString(m) }
+func (*ReminderRules) ProtoMessage()               {}
+func (*ReminderRules) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *ReminderRules) GetLongTracking() *google_protobuf.Duration {
+	if m != nil {
+		return m.LongTracking
+	}
+	return nil
+}
+
+func (m *ReminderRules) GetEndOfDay() *google_protobuf.Duration {
+	if m != nil {
+		return m.EndOfDay
+	}
+	return nil
+}
+
+func (m *ReminderRules) GetWorkStart() *google_protobuf.Duration {
+	if m != nil {
+		return m.WorkStart
+	}
+	return nil
+}
+
+func (m *ReminderRules) GetWorkEnd() *google_protobuf.Duration {
+	if m != nil {
+		return m.WorkEnd
+	}
+	return nil
+}
+
+func (m *ReminderRules) GetUntrackedAfter() *google_protobuf.Duration {
+	if m != nil {
+		return m.UntrackedAfter
+	}
+	return nil
+}
+
+type GetReminderRulesReq struct {
+	Device string `protobuf:"bytes,1,opt,name=device" json:"device,omitempty"`
+}
+
+func (m *GetReminderRulesReq) Reset()                    { *m = GetReminderRulesReq{} }
+func (m *GetReminderRulesReq) String() string            { return proto.CompactTextString(m) }
+func (*GetReminderRulesReq) ProtoMessage()               {}
+func (*GetReminderRulesReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *GetReminderRulesReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+type GetReminderRulesResp struct {
+	Rules *ReminderRules `protobuf:"bytes,1,opt,name=rules" json:"rules,omitempty"`
+}
+
+func (m *GetReminderRulesResp) Reset()                    { *m = GetReminderRulesResp{} }
+func (m *GetReminderRulesResp) String() string            { return proto.CompactTextString(m) }
+func (*GetReminderRulesResp) ProtoMessage()               {}
+func (*GetReminderRulesResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *GetReminderRulesResp) GetRules() *ReminderRules {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type UpdateReminderRulesReq struct {
+	Rules  *ReminderRules `protobuf:"bytes,1,opt,name=rules" json:"rules,omitempty"`
+	Device string         `protobuf:"bytes,2,opt,name=device" json:"device,omitempty"`
+}
+
+func (m *UpdateReminderRulesReq) Reset()                    { *m = UpdateReminderRulesReq{} }
+func (m *UpdateReminderRulesReq) String() string            { return proto.CompactTextString(m) }
+func (*UpdateReminderRulesReq) ProtoMessage()               {}
+func (*UpdateReminderRulesReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *UpdateReminderRulesReq) GetRules() *ReminderRules {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *UpdateReminderRulesReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+type UpdateReminderRulesResp struct {
+	Rules *ReminderRules `protobuf:"bytes,1,opt,name=rules" json:"rules,omitempty"`
+}
+
+func (m *UpdateReminderRulesResp) Reset()                    { *m = UpdateReminderRulesResp{} }
+func (m *UpdateReminderRulesResp) String() string            { return proto.CompactTextString(m) }
+func (*UpdateReminderRulesResp) ProtoMessage()               {}
+func (*UpdateReminderRulesResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *UpdateReminderRulesResp) GetRules() *ReminderRules {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+// Profile is a named layout of the sides of the device, own_account is set
+// when the profile tracks to its own account.
+type Profile struct {
+	Name       string        `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	OwnAccount bool          `protobuf:"varint,2,opt,name=own_account,json=ownAccount" json:"own_account,omitempty"`
+	Layout     []*LayoutSide `protobuf:"bytes,3,rep,name=layout" json:"layout,omitempty"`
+}
+
+func (m *Profile) Reset()                    { *m = Profile{} }
+func (m *Profile) String() string            { return proto.CompactTextString(m) }
+func (*Profile) ProtoMessage()               {}
+func (*Profile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *Profile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Profile) GetOwnAccount() bool {
+	if m != nil {
+		return m.OwnAccount
+	}
+	return false
+}
+
+func (m *Profile) GetLayout() []*LayoutSide {
+	if m != nil {
+		return m.Layout
+	}
+	return nil
+}
+
+type ListProfilesReq struct {
+	Device string `protobuf:"bytes,1,opt,name=device" json:"device,omitempty"`
+}
+
+func (m *ListProfilesReq) Reset()                    { *m = ListProfilesReq{} }
+func (m *ListProfilesReq) String() string            { return proto.CompactTextString(m) }
+func (*ListProfilesReq) ProtoMessage()               {}
+func (*ListProfilesReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *ListProfilesReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+type ListProfilesResp struct {
+	Profiles []*Profile `protobuf:"bytes,1,rep,name=profiles" json:"profiles,omitempty"`
+	Active   string     `protobuf:"bytes,2,opt,name=active" json:"active,omitempty"`
+}
+
+func (m *ListProfilesResp) Reset()                    { *m = ListProfilesResp{} }
+func (m *ListProfilesResp) String() string            { return proto.CompactTextString(m) }
+func (*ListProfilesResp) ProtoMessage()               {}
+func (*ListProfilesResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *ListProfilesResp) GetProfiles() []*Profile {
+	if m != nil {
+		return m.Profiles
+	}
+	return nil
+}
+
+func (m *ListProfilesResp) GetActive() string {
+	if m != nil {
+		return m.Active
+	}
+	return ""
+}
+
+type SwitchProfileReq struct {
+	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Device string `protobuf:"bytes,2,opt,name=device" json:"device,omitempty"`
+}
+
+func (m *SwitchProfileReq) Reset()                    { *m = SwitchProfileReq{} }
+func (m *SwitchProfileReq) String() string            { return proto.CompactTextString(m) }
+func (*SwitchProfileReq) ProtoMessage()               {}
+func (*SwitchProfileReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *SwitchProfileReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SwitchProfileReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+type SwitchProfileResp struct {
+	Profile *Profile        `protobuf:"bytes,1,opt,name=profile" json:"profile,omitempty"`
+	Changes []*LayoutChange `protobuf:"bytes,2,rep,name=changes" json:"changes,omitempty"`
+}
+
+func (m *SwitchProfileResp) Reset()                    { *m = SwitchProfileResp{} }
+func (m *SwitchProfileResp) String() string            { return proto.CompactTextString(m) }
+func (*SwitchProfileResp) ProtoMessage()               {}
+func (*SwitchProfileResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *SwitchProfileResp) GetProfile() *Profile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+func (m *SwitchProfileResp) GetChanges() []*LayoutChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+// Device is a device managed by zeid, activity is the activity tracked on it.
+type Device struct {
+	Serial    string    `protobuf:"bytes,1,opt,name=serial" json:"serial,omitempty"`
+	Connected bool      `protobuf:"varint,2,opt,name=connected" json:"connected,omitempty"`
+	Profile   string    `protobuf:"bytes,3,opt,name=profile" json:"profile,omitempty"`
+	Activity  *Activity `protobuf:"bytes,4,opt,name=activity" json:"activity,omitempty"`
+	IsIdle    bool      `protobuf:"varint,5,opt,name=is_idle,json=isIdle" json:"is_idle,omitempty"`
+}
+
+func (m *Device) Reset()                    { *m = Device{} }
+func (m *Device) String() string            { return proto.CompactTextString(m) }
+func (*Device) ProtoMessage()               {}
+func (*Device) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *Device) GetSerial() string {
+	if m != nil {
+		return m.Serial
+	}
+	return ""
+}
+
+func (m *Device) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+func (m *Device) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
+func (m *Device) GetActivity() *Activity {
+	if m != nil {
+		return m.Activity
+	}
+	return nil
+}
+
+func (m *Device) GetIsIdle() bool {
+	if m != nil {
+		return m.IsIdle
+	}
+	return false
+}
+
+type ListDevicesReq struct {
+}
+
+func (m *ListDevicesReq) Reset()                    { *m = ListDevicesReq{} }
+func (m *ListDevicesReq) String() string            { return proto.CompactTextString(m) }
+func (*ListDevicesReq) ProtoMessage()               {}
+func (*ListDevicesReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+type ListDevicesResp struct {
+	Devices []*Device `protobuf:"bytes,1,rep,name=devices" json:"devices,omitempty"`
+}
+
+func (m *ListDevicesResp) Reset()                    { *m = ListDevicesResp{} }
+func (m *ListDevicesResp) String() string            { return proto.CompactTextString(m) }
+func (*ListDevicesResp) ProtoMessage()               {}
+func (*ListDevicesResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *ListDevicesResp) GetDevices() []*Device {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+type DeviceInfoReq struct {
+	Device string `protobuf:"bytes,1,opt,name=device" json:"device,omitempty"`
+}
+
+func (m *DeviceInfoReq) Reset()                    { *m = DeviceInfoReq{} }
+func (m *DeviceInfoReq) String() string            { return proto.CompactTextString(m) }
+func (*DeviceInfoReq) ProtoMessage()               {}
+func (*DeviceInfoReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *DeviceInfoReq) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+// DeviceInfoResp is read from the standard device information and battery
+// services of the device, fields it doesn't provide are left empty.
+type DeviceInfoResp struct {
+	Serial           string `protobuf:"bytes,1,opt,name=serial" json:"serial,omitempty"`
+	Connected        bool   `protobuf:"varint,2,opt,name=connected" json:"connected,omitempty"`
+	Manufacturer     string `protobuf:"bytes,3,opt,name=manufacturer" json:"manufacturer,omitempty"`
+	Model            string `protobuf:"bytes,4,opt,name=model" json:"model,omitempty"`
+	SerialNumber     string `protobuf:"bytes,5,opt,name=serial_number,json=serialNumber" json:"serial_number,omitempty"`
+	HardwareRevision string `protobuf:"bytes,6,opt,name=hardware_revision,json=hardwareRevision" json:"hardware_revision,omitempty"`
+	FirmwareRevision string `protobuf:"bytes,7,opt,name=firmware_revision,json=firmwareRevision" json:"firmware_revision,omitempty"`
+	SoftwareRevision string `protobuf:"bytes,8,opt,name=software_revision,json=softwareRevision" json:"software_revision,omitempty"`
+	HasBattery       bool   `protobuf:"varint,9,opt,name=has_battery,json=hasBattery" json:"has_battery,omitempty"`
+	BatteryLevel     int64  `protobuf:"varint,10,opt,name=battery_level,json=batteryLevel" json:"battery_level,omitempty"`
+}
+
+func (m *DeviceInfoResp) Reset()                    { *m = DeviceInfoResp{} }
+func (m *DeviceInfoResp) String() string            { return proto.CompactTextString(m) }
+func (*DeviceInfoResp) ProtoMessage()               {}
+func (*DeviceInfoResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *DeviceInfoResp) GetSerial() string {
+	if m != nil {
+		return m.Serial
+	}
+	return ""
+}
+
+func (m *DeviceInfoResp) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+func (m *DeviceInfoResp) GetManufacturer() string {
+	if m != nil {
+		return m.Manufacturer
+	}
+	return ""
+}
+
+func (m *DeviceInfoResp) GetModel() string {
+	if m != nil {
+		return m.Model
+	}
+	return ""
+}
+
+func (m *DeviceInfoResp) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+func (m *DeviceInfoResp) GetHardwareRevision() string {
+	if m != nil {
+		return m.HardwareRevision
+	}
+	return ""
+}
+
+func (m *DeviceInfoResp) GetFirmwareRevision() string {
+	if m != nil {
+		return m.FirmwareRevision
+	}
+	return ""
+}
+
+func (m *DeviceInfoResp) GetSoftwareRevision() string {
+	if m != nil {
+		return m.SoftwareRevision
+	}
+	return ""
+}
+
+func (m *DeviceInfoResp) GetHasBattery() bool {
+	if m != nil {
+		return m.HasBattery
+	}
+	return false
+}
+
+func (m *DeviceInfoResp) GetBatteryLevel() int64 {
+	if m != nil {
+		return m.BatteryLevel
+	}
+	return 0
+}
+
+type HealthReq struct {
+}
+
+func (m *HealthReq) Reset()                    { *m = HealthReq{} }
+func (m *HealthReq) String() string            { return proto.CompactTextString(m) }
+func (*HealthReq) ProtoMessage()               {}
+func (*HealthReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+// DeviceHealth is the state of the connections of a device, to the device
+// over BLE and to the API of its account. api_error is the error of the
+// request checking the API, if any. token_valid is only meaningful when
+// token_checked, the API may fail before checking the token. queue_depth is
+// the number of activity changes waiting to be tracked.
+type DeviceHealth struct {
+	Serial          string                      `protobuf:"bytes,1,opt,name=serial" json:"serial,omitempty"`
+	Connected       bool                        `protobuf:"varint,2,opt,name=connected" json:"connected,omitempty"`
+	Rssi            int64                       `protobuf:"varint,3,opt,name=rssi" json:"rssi,omitempty"`
+	LastOrientation *google_protobuf1.Timestamp `protobuf:"bytes,4,opt,name=last_orientation,json=lastOrientation" json:"last_orientation,omitempty"`
+	ApiReachable    bool                        `protobuf:"varint,5,opt,name=api_reachable,json=apiReachable" json:"api_reachable,omitempty"`
+	TokenValid      bool                        `protobuf:"varint,6,opt,name=token_valid,json=tokenValid" json:"token_valid,omitempty"`
+	ApiError        string                      `protobuf:"bytes,7,opt,name=api_error,json=apiError" json:"api_error,omitempty"`
+	ApiLatency      *google_protobuf.Duration   `protobuf:"bytes,8,opt,name=api_latency,json=apiLatency" json:"api_latency,omitempty"`
+	Profile         string                      `protobuf:"bytes,9,opt,name=profile" json:"profile,omitempty"`
+	TokenChecked    bool                        `protobuf:"varint,10,opt,name=token_checked,json=tokenChecked" json:"token_checked,omitempty"`
+	QueueDepth      int64                       `protobuf:"varint,11,opt,name=queue_depth,json=queueDepth" json:"queue_depth,omitempty"`
+}
+
+func (m *DeviceHealth) Reset()                    { *m = DeviceHealth{} }
+func (m *DeviceHealth) String() string            { return proto.CompactTextString(m) }
+func (*DeviceHealth) ProtoMessage()               {}
+func (*DeviceHealth) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *DeviceHealth) GetSerial() string {
+	if m != nil {
+		return m.Serial
+	}
+	return ""
+}
+
+func (m *DeviceHealth) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+func (m *DeviceHealth) GetRssi() int64 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+func (m *DeviceHealth) GetLastOrientation() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.LastOrientation
+	}
+	return nil
+}
+
+func (m *DeviceHealth) GetApiReachable() bool {
+	if m != nil {
+		return m.ApiReachable
+	}
+	return false
+}
+
+func (m *DeviceHealth) GetTokenValid() bool {
+	if m != nil {
+		return m.TokenValid
+	}
+	return false
+}
+
+func (m *DeviceHealth) GetApiError() string {
+	if m != nil {
+		return m.ApiError
+	}
+	return ""
+}
+
+func (m *DeviceHealth) GetApiLatency() *google_protobuf.Duration {
+	if m != nil {
+		return m.ApiLatency
+	}
+	return nil
+}
+
+func (m *DeviceHealth) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
+func (m *DeviceHealth) GetTokenChecked() bool {
+	if m != nil {
+		return m.TokenChecked
+	}
+	return false
+}
+
+func (m *DeviceHealth) GetQueueDepth() int64 {
+	if m != nil {
+		return m.QueueDepth
+	}
+	return 0
+}
+
+type HealthResp struct {
+	Version   string                      `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	StartedAt *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt" json:"started_at,omitempty"`
+	Devices   []*DeviceHealth             `protobuf:"bytes,3,rep,name=devices" json:"devices,omitempty"`
+}
+
+func (m *HealthResp) Reset()                    { *m = HealthResp{} }
+func (m *HealthResp) String() string            { return proto.CompactTextString(m) }
+func (*HealthResp) ProtoMessage()               {}
+func (*HealthResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *HealthResp) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *HealthResp) GetStartedAt() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *HealthResp) GetDevices() []*DeviceHealth {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
 
 func init() {
 	proto.RegisterType((*Activity)(nil), "zei.zeid.Activity")
@@ -183,36 +1462,181 @@ func init() {
 	proto.RegisterType((*ListActivitiesResp)(nil), "zei.zeid.ListActivitiesResp")
 	proto.RegisterType((*CurrentActivityReq)(nil), "zei.zeid.CurrentActivityReq")
 	proto.RegisterType((*CurrentActivityResp)(nil), "zei.zeid.CurrentActivityResp")
+	proto.RegisterType((*FocusSession)(nil), "zei.zeid.FocusSession")
 	proto.RegisterType((*AssignActivityReq)(nil), "zei.zeid.AssignActivityReq")
 	proto.RegisterType((*AssignActivityResp)(nil), "zei.zeid.AssignActivityResp")
+	proto.RegisterType((*Side)(nil), "zei.zeid.Side")
+	proto.RegisterType((*ListSidesReq)(nil), "zei.zeid.ListSidesReq")
+	proto.RegisterType((*ListSidesResp)(nil), "zei.zeid.ListSidesResp")
+	proto.RegisterType((*StartActivityReq)(nil), "zei.zeid.StartActivityReq")
+	proto.RegisterType((*StartActivityResp)(nil), "zei.zeid.StartActivityResp")
+	proto.RegisterType((*StopActivityReq)(nil), "zei.zeid.StopActivityReq")
+	proto.RegisterType((*StopActivityResp)(nil), "zei.zeid.StopActivityResp")
+	proto.RegisterType((*TimeEntry)(nil), "zei.zeid.TimeEntry")
+	proto.RegisterType((*ActivityTotal)(nil), "zei.zeid.ActivityTotal")
+	proto.RegisterType((*HistoryReq)(nil), "zei.zeid.HistoryReq")
+	proto.RegisterType((*HistoryResp)(nil), "zei.zeid.HistoryResp")
+	proto.RegisterType((*LayoutSide)(nil), "zei.zeid.LayoutSide")
+	proto.RegisterType((*LayoutChange)(nil), "zei.zeid.LayoutChange")
+	proto.RegisterType((*ApplyLayoutReq)(nil), "zei.zeid.ApplyLayoutReq")
+	proto.RegisterType((*ApplyLayoutResp)(nil), "zei.zeid.ApplyLayoutResp")
+	proto.RegisterType((*ResolveIdleGapReq)(nil), "zei.zeid.ResolveIdleGapReq")
+	proto.RegisterType((*ResolveIdleGapResp)(nil), "zei.zeid.ResolveIdleGapResp")
+	proto.RegisterType((*ReminderRules)(nil), "zei.zeid.ReminderRules")
+	proto.RegisterType((*GetReminderRulesReq)(nil), "zei.zeid.GetReminderRulesReq")
+	proto.RegisterType((*GetReminderRulesResp)(nil), "zei.zeid.GetReminderRulesResp")
+	proto.RegisterType((*UpdateReminderRulesReq)(nil), "zei.zeid.UpdateReminderRulesReq")
+	proto.RegisterType((*UpdateReminderRulesResp)(nil), "zei.zeid.UpdateReminderRulesResp")
+	proto.RegisterType((*Profile)(nil), "zei.zeid.Profile")
+	proto.RegisterType((*ListProfilesReq)(nil), "zei.zeid.ListProfilesReq")
+	proto.RegisterType((*ListProfilesResp)(nil), "zei.zeid.ListProfilesResp")
+	proto.RegisterType((*SwitchProfileReq)(nil), "zei.zeid.SwitchProfileReq")
+	proto.RegisterType((*SwitchProfileResp)(nil), "zei.zeid.SwitchProfileResp")
+	proto.RegisterType((*Device)(nil), "zei.zeid.Device")
+	proto.RegisterType((*ListDevicesReq)(nil), "zei.zeid.ListDevicesReq")
+	proto.RegisterType((*ListDevicesResp)(nil), "zei.zeid.ListDevicesResp")
+	proto.RegisterType((*DeviceInfoReq)(nil), "zei.zeid.DeviceInfoReq")
+	proto.RegisterType((*DeviceInfoResp)(nil), "zei.zeid.DeviceInfoResp")
+	proto.RegisterType((*HealthReq)(nil), "zei.zeid.HealthReq")
+	proto.RegisterType((*DeviceHealth)(nil), "zei.zeid.DeviceHealth")
+	proto.RegisterType((*HealthResp)(nil), "zei.zeid.HealthResp")
+	proto.RegisterEnum("zei.zeid.FocusPhase", FocusPhase_name, FocusPhase_value)
+	proto.RegisterEnum("zei.zeid.LayoutChangeKind", LayoutChangeKind_name, LayoutChangeKind_value)
 }
 
 func init() { proto.RegisterFile("rpc/zeid/service.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 378 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xc1, 0x6e, 0xda, 0x40,
-	0x10, 0x86, 0x65, 0x0c, 0xd4, 0x0c, 0x12, 0x15, 0x03, 0x6a, 0x2d, 0x5a, 0x54, 0xcb, 0x27, 0x4e,
-	0x46, 0xa2, 0x7d, 0x01, 0xda, 0x13, 0x6a, 0xd5, 0x83, 0xdb, 0x13, 0x17, 0xcb, 0xf1, 0x8e, 0xd0,
-	0x48, 0x60, 0x3b, 0xbb, 0x1b, 0x14, 0x90, 0x72, 0xce, 0xe3, 0xe6, 0x15, 0x22, 0x2f, 0x36, 0x31,
-	0x38, 0xc9, 0xcd, 0xfe, 0x66, 0xc7, 0xfa, 0xfe, 0x7f, 0x65, 0xf8, 0x24, 0xf3, 0x64, 0x7e, 0x24,
-	0x16, 0x73, 0x45, 0x72, 0xcf, 0x09, 0x05, 0xb9, 0xcc, 0x74, 0x86, 0xce, 0x91, 0x38, 0x28, 0xb8,
-	0xff, 0x68, 0x81, 0xb3, 0x4c, 0x34, 0xef, 0x59, 0x1f, 0x70, 0x00, 0x2d, 0x16, 0xae, 0xe5, 0x59,
-	0xb3, 0x5e, 0xd8, 0x62, 0x81, 0x08, 0xed, 0x34, 0xde, 0x91, 0xdb, 0x32, 0xc4, 0x3c, 0xe3, 0x18,
-	0x3a, 0x49, 0xb6, 0xcd, 0xa4, 0x6b, 0x1b, 0x78, 0x7a, 0x41, 0x0f, 0xfa, 0x9c, 0x6a, 0xda, 0xc8,
-	0x58, 0x73, 0x96, 0xba, 0x6d, 0x33, 0xab, 0x23, 0xfc, 0x06, 0x7d, 0x41, 0x85, 0x42, 0xa4, 0x58,
-	0x90, 0xdb, 0xf1, 0xac, 0x99, 0x1d, 0xc2, 0x09, 0xfd, 0x63, 0x41, 0xfe, 0x08, 0x86, 0x7f, 0x58,
-	0xe9, 0x52, 0x86, 0x49, 0x85, 0x74, 0xeb, 0xdf, 0x03, 0x5e, 0x43, 0x95, 0xe3, 0x02, 0x20, 0x3e,
-	0x13, 0xd7, 0xf2, 0xec, 0x59, 0x7f, 0x81, 0x41, 0x95, 0x29, 0xa8, 0xf2, 0x84, 0xb5, 0x53, 0x18,
-	0xc0, 0x28, 0xb9, 0x93, 0x92, 0x52, 0x1d, 0x95, 0xf4, 0x10, 0xb1, 0x28, 0xa3, 0x0d, 0xcb, 0x51,
-	0xb5, 0xb9, 0x12, 0xfe, 0x18, 0xf0, 0xd7, 0x25, 0x2c, 0x7c, 0x1e, 0x60, 0xd4, 0xa0, 0x2a, 0xc7,
-	0x00, 0x9c, 0xea, 0xa3, 0xa6, 0xbe, 0xd7, 0x75, 0xce, 0x67, 0x70, 0x0a, 0xa0, 0x74, 0x2c, 0x75,
-	0xa4, 0xf9, 0x5c, 0x6f, 0xcf, 0x90, 0xff, 0xbc, 0x23, 0xfc, 0x0c, 0x1f, 0x58, 0x45, 0x2c, 0xb6,
-	0x64, 0x5a, 0x76, 0xc2, 0x2e, 0xab, 0x95, 0xd8, 0x92, 0xff, 0x03, 0x86, 0x4b, 0xa5, 0x78, 0x93,
-	0xd6, 0x9c, 0x8a, 0x66, 0xeb, 0x89, 0x4e, 0xd7, 0x57, 0x45, 0x2f, 0xa3, 0x5c, 0x6f, 0xa9, 0x7c,
-	0xf1, 0x64, 0x81, 0xbd, 0x26, 0xc6, 0xdf, 0x30, 0xb8, 0xac, 0x18, 0xbf, 0xbc, 0xb8, 0x37, 0x6e,
-	0x64, 0xf2, 0xf5, 0xed, 0xa1, 0xca, 0xf1, 0x2f, 0x7c, 0xbc, 0xea, 0x07, 0x6b, 0x0b, 0xcd, 0x42,
-	0x27, 0xd3, 0x77, 0xa6, 0x2a, 0x2f, 0xe4, 0x2e, 0xd5, 0xeb, 0x72, 0x8d, 0x2a, 0xea, 0x72, 0xcd,
-	0xc4, 0x3f, 0xbb, 0xeb, 0x76, 0x31, 0xba, 0xe9, 0x9a, 0x9f, 0xe0, 0xfb, 0x73, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x29, 0x60, 0x9b, 0x77, 0x1e, 0x03, 0x00, 0x00,
+	// 2091 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0x5f, 0x73, 0xe4, 0x46,
+	0x11, 0xcf, 0x6a, 0xff, 0xf7, 0xae, 0xed, 0xf5, 0xf8, 0xb0, 0x75, 0xba, 0x23, 0x77, 0x51, 0x28,
+	0x48, 0x7c, 0x77, 0xeb, 0x94, 0x0f, 0x08, 0x50, 0x90, 0xd4, 0xda, 0xe7, 0xdc, 0x1d, 0x67, 0xec,
+	0x20, 0xfb, 0x20, 0xb9, 0x2a, 0x4a, 0x25, 0xaf, 0x66, 0xbd, 0x53, 0xd6, 0x4a, 0x13, 0xcd, 0xac,
+	0xcd, 0xe6, 0x09, 0x1e, 0x80, 0x0f, 0xc0, 0x7b, 0xaa, 0xf8, 0x0a, 0x14, 0x8f, 0xbc, 0xf0, 0xc4,
+	0xf7, 0xe1, 0x13, 0x50, 0x33, 0x23, 0xad, 0x46, 0xfb, 0xd7, 0xeb, 0x37, 0x4d, 0xf7, 0x6f, 0xd4,
+	0x3d, 0xfd, 0x6f, 0xba, 0x07, 0xb6, 0x63, 0xda, 0xdd, 0xfb, 0x16, 0x13, 0x7f, 0x8f, 0xe1, 0xf8,
+	0x9a, 0x74, 0x71, 0x9b, 0xc6, 0x11, 0x8f, 0x50, 0xed, 0x5b, 0x4c, 0xda, 0x82, 0x6e, 0xbd, 0x7f,
+	0x19, 0x45, 0x97, 0x01, 0xde, 0x93, 0xf4, 0x8b, 0x61, 0x6f, 0xcf, 0x1f, 0xc6, 0x1e, 0x27, 0x51,
+	0xa8, 0x90, 0xd6, 0xa3, 0x49, 0x3e, 0x27, 0x03, 0xcc, 0xb8, 0x37, 0xa0, 0x0a, 0x60, 0xff, 0xad,
+	0x00, 0xb5, 0x4e, 0x97, 0x93, 0x6b, 0xc2, 0x47, 0x68, 0x1d, 0x0c, 0xe2, 0x9b, 0x85, 0xc7, 0x85,
+	0x8f, 0xea, 0x8e, 0x41, 0x7c, 0x84, 0xa0, 0x14, 0x7a, 0x03, 0x6c, 0x1a, 0x92, 0x22, 0xbf, 0xd1,
+	0x3d, 0x28, 0x77, 0xa3, 0x20, 0x8a, 0xcd, 0xa2, 0x24, 0xaa, 0x05, 0x7a, 0x0c, 0x0d, 0x12, 0x72,
+	0x7c, 0xa9, 0x84, 0x9b, 0x25, 0xc9, 0xd3, 0x49, 0xe8, 0x11, 0x34, 0x7c, 0x2c, 0xce, 0xe0, 0x32,
+	0xe2, 0x63, 0xb3, 0xfc, 0xb8, 0xf0, 0x51, 0xd1, 0x01, 0x45, 0x3a, 0x23, 0x3e, 0xb6, 0x9f, 0xc0,
+	0xe6, 0x31, 0x61, 0x3c, 0x51, 0x86, 0x60, 0xe6, 0xe0, 0x6f, 0xd0, 0x36, 0x54, 0x14, 0x24, 0xd1,
+	0x2a, 0x59, 0xd9, 0x7f, 0x04, 0x34, 0x09, 0x66, 0x14, 0xed, 0x03, 0x78, 0x63, 0x8a, 0x59, 0x78,
+	0x5c, 0xfc, 0xa8, 0xb1, 0x8f, 0xda, 0xa9, 0xb1, 0xda, 0xe9, 0x39, 0x1d, 0x0d, 0x85, 0xda, 0xb0,
+	0xd5, 0x1d, 0xc6, 0x31, 0x0e, 0xb9, 0x9b, 0x50, 0x47, 0x2e, 0xf1, 0x93, 0x23, 0x6f, 0x26, 0xac,
+	0x74, 0xe7, 0x6b, 0xdf, 0x7e, 0x0a, 0xe8, 0x30, 0x4f, 0x5c, 0xa4, 0xe7, 0x7f, 0x0d, 0xd8, 0x9a,
+	0x82, 0x33, 0x8a, 0xda, 0x50, 0x4b, 0xa5, 0xc9, 0x1d, 0xb3, 0xf5, 0x1c, 0x63, 0xd0, 0x0e, 0x54,
+	0x09, 0x73, 0x89, 0x1f, 0x60, 0x69, 0xf7, 0x9a, 0x53, 0x21, 0xec, 0xb5, 0x1f, 0x60, 0xf4, 0x73,
+	0x00, 0xc6, 0xbd, 0x98, 0xbb, 0xc2, 0xb1, 0xd2, 0xee, 0x8d, 0x7d, 0xab, 0xad, 0xbc, 0xde, 0x4e,
+	0xbd, 0xde, 0x3e, 0x4f, 0xbd, 0xee, 0xd4, 0x25, 0x5a, 0xac, 0xd1, 0x4f, 0xa0, 0x96, 0x46, 0x8b,
+	0x74, 0x47, 0x63, 0xff, 0xfe, 0xd4, 0xc6, 0x17, 0x09, 0xc0, 0x19, 0x43, 0xd1, 0x53, 0x28, 0xf7,
+	0xa2, 0xee, 0x90, 0x99, 0x15, 0xb9, 0x67, 0x3b, 0xd3, 0xfb, 0x0b, 0x41, 0x3e, 0xc3, 0x8c, 0x89,
+	0x0d, 0x0a, 0x84, 0x3e, 0x86, 0x56, 0xe2, 0xf6, 0x6e, 0x14, 0x86, 0xb8, 0xcb, 0xb1, 0x6f, 0x56,
+	0xe5, 0x09, 0x36, 0x14, 0xfd, 0x30, 0x25, 0x6b, 0x36, 0xac, 0xe9, 0x36, 0xfc, 0x75, 0xa9, 0x66,
+	0xb4, 0x8a, 0xf6, 0x5f, 0x0d, 0x68, 0xea, 0x02, 0x56, 0x36, 0xe1, 0x2e, 0x94, 0x69, 0xdf, 0x63,
+	0x2a, 0x9a, 0xd7, 0xf7, 0xef, 0x4d, 0xe8, 0xfd, 0xa5, 0xe0, 0x39, 0x0a, 0x22, 0x83, 0x7c, 0xd4,
+	0x4d, 0x8c, 0x5d, 0x74, 0xd4, 0x42, 0x28, 0x28, 0x3f, 0x98, 0xb4, 0x73, 0xd1, 0x49, 0x56, 0xe8,
+	0x39, 0x54, 0x71, 0xe8, 0x33, 0xd7, 0xe3, 0x66, 0x79, 0xa9, 0x03, 0x2a, 0x02, 0xda, 0xe1, 0xe8,
+	0x53, 0xa8, 0xc7, 0x78, 0xe0, 0x91, 0x90, 0x84, 0x97, 0x66, 0x65, 0x99, 0xf9, 0x33, 0xac, 0xfd,
+	0x97, 0x02, 0x6c, 0x76, 0x18, 0x23, 0x97, 0xa1, 0x1e, 0x80, 0x8f, 0xa0, 0xa1, 0x87, 0xaf, 0x8a,
+	0xc2, 0x34, 0xce, 0x47, 0xaf, 0x65, 0x2e, 0xcb, 0xc4, 0x33, 0xa4, 0xea, 0xf2, 0x1b, 0x7d, 0x08,
+	0x6b, 0xe3, 0x4d, 0x32, 0xd1, 0x55, 0x4e, 0x37, 0x53, 0xe2, 0x89, 0x48, 0xf8, 0xcc, 0x2d, 0xa5,
+	0x5c, 0x68, 0x7f, 0x05, 0x68, 0x52, 0x8d, 0x3b, 0x04, 0xf6, 0x0c, 0xb5, 0xec, 0x13, 0x28, 0x89,
+	0x8a, 0x20, 0x24, 0x87, 0xc3, 0xc1, 0x05, 0x8e, 0xe5, 0x9f, 0x8a, 0x4e, 0xb2, 0xca, 0xc9, 0x30,
+	0x96, 0xcb, 0xb0, 0x7f, 0x08, 0x4d, 0x51, 0x2c, 0xc4, 0x3f, 0x17, 0x16, 0x95, 0xaf, 0x60, 0x4d,
+	0xc3, 0x31, 0x8a, 0x7e, 0x00, 0x65, 0xa1, 0x50, 0x5a, 0x4a, 0xd6, 0x33, 0x29, 0x02, 0xe3, 0x28,
+	0x26, 0xfa, 0x00, 0x9a, 0x69, 0x05, 0xd1, 0x8e, 0xd2, 0x48, 0x68, 0xb2, 0xb6, 0x51, 0x68, 0x9d,
+	0x89, 0xbc, 0x5b, 0xc9, 0x63, 0x99, 0x9a, 0x86, 0xae, 0xe6, 0xad, 0xbc, 0x66, 0x1f, 0xc2, 0xe6,
+	0x84, 0xc4, 0xd5, 0x9d, 0x63, 0x7f, 0x0c, 0x1b, 0x67, 0x3c, 0xa2, 0xb7, 0x29, 0x74, 0x07, 0xd0,
+	0xca, 0x43, 0xef, 0x20, 0xee, 0x3f, 0x05, 0xa8, 0x8b, 0x44, 0x39, 0x0a, 0x79, 0x3c, 0x7d, 0x19,
+	0xad, 0xe8, 0xf5, 0x71, 0x65, 0xc4, 0xbe, 0x48, 0xcc, 0xe2, 0x2d, 0x2b, 0x23, 0xf6, 0x3b, 0x5c,
+	0x6d, 0x8d, 0x28, 0x55, 0x5b, 0x6f, 0x55, 0x54, 0x25, 0xba, 0xc3, 0xed, 0x6b, 0x58, 0x4b, 0x75,
+	0x39, 0x8f, 0xb8, 0x17, 0xac, 0x9c, 0x10, 0x7a, 0x55, 0x36, 0x6e, 0x5d, 0x95, 0xed, 0x3f, 0x15,
+	0x00, 0x5e, 0x11, 0xc6, 0xa3, 0x58, 0xba, 0xa9, 0x0d, 0xa5, 0x5e, 0x1c, 0x0d, 0xcc, 0xc2, 0x52,
+	0xdd, 0x25, 0x0e, 0xed, 0x82, 0xc1, 0x23, 0xd3, 0x58, 0x8a, 0x36, 0x78, 0xa4, 0x85, 0x40, 0x31,
+	0x17, 0x02, 0xdf, 0x15, 0xa0, 0x31, 0x56, 0x81, 0x51, 0xf4, 0x4c, 0x94, 0x45, 0x1e, 0x67, 0x57,
+	0xf1, 0x56, 0x76, 0xf0, 0xb1, 0x9b, 0x9d, 0x14, 0x83, 0xf6, 0xa0, 0xc2, 0x85, 0xc5, 0x98, 0x69,
+	0x48, 0xf4, 0xce, 0xb4, 0x99, 0xa4, 0x45, 0x9d, 0x04, 0x86, 0xf6, 0xa0, 0x2c, 0xbf, 0xcc, 0xe2,
+	0x32, 0x33, 0x29, 0x9c, 0xed, 0x02, 0x1c, 0x7b, 0xa3, 0x68, 0xc8, 0x17, 0x56, 0x97, 0xa9, 0xf4,
+	0x32, 0x66, 0x14, 0xc5, 0x99, 0x5d, 0x90, 0xfd, 0xcf, 0x02, 0x34, 0x95, 0x84, 0xc3, 0xbe, 0x17,
+	0x5e, 0x62, 0xe1, 0x86, 0x2b, 0x12, 0xaa, 0x28, 0x5e, 0xdf, 0xb7, 0xb2, 0x13, 0xe9, 0xa8, 0x37,
+	0x24, 0xf4, 0x1d, 0x89, 0x9b, 0x59, 0xa4, 0xf5, 0x00, 0x2a, 0xde, 0x22, 0x80, 0xda, 0x50, 0xa3,
+	0x31, 0xbe, 0x26, 0xd1, 0x90, 0x99, 0xa5, 0xf9, 0xf8, 0x14, 0x63, 0x0f, 0x60, 0xbd, 0x43, 0x69,
+	0x30, 0x52, 0x2a, 0x89, 0xe0, 0xd9, 0xcd, 0x97, 0xbd, 0x7b, 0x93, 0x6a, 0xeb, 0xc5, 0x6f, 0x07,
+	0xaa, 0x7e, 0x3c, 0x72, 0xe3, 0xa1, 0x8a, 0xd6, 0x9a, 0x53, 0xf1, 0xe3, 0x91, 0x33, 0x0c, 0xe7,
+	0x46, 0xc9, 0x1f, 0x60, 0x23, 0x27, 0x8e, 0x51, 0xf4, 0x09, 0x54, 0xbb, 0xd2, 0x12, 0xa9, 0xc4,
+	0xed, 0xd9, 0x86, 0x72, 0x52, 0x18, 0x32, 0xa1, 0xea, 0x51, 0x1a, 0x10, 0xec, 0x27, 0x52, 0xd3,
+	0xa5, 0xfd, 0x39, 0x6c, 0x3a, 0x98, 0x45, 0xc1, 0x35, 0x16, 0xed, 0xd1, 0x4b, 0x8f, 0x8a, 0x03,
+	0x21, 0x28, 0x5d, 0x61, 0x4c, 0xa5, 0x1b, 0x6a, 0x8e, 0xfc, 0x9e, 0x57, 0x5d, 0xed, 0x7f, 0x15,
+	0x00, 0x4d, 0xfe, 0xe1, 0x0e, 0xf7, 0x5a, 0x9a, 0x80, 0xc6, 0x4a, 0x09, 0x58, 0xbc, 0x55, 0x02,
+	0xca, 0xe3, 0x50, 0x55, 0x98, 0xe4, 0x71, 0x28, 0xb7, 0xff, 0x6d, 0xc0, 0x9a, 0x83, 0x07, 0x24,
+	0xf4, 0x71, 0xec, 0x0c, 0x45, 0x57, 0xf2, 0x19, 0xac, 0x05, 0x51, 0x78, 0xe9, 0xf2, 0xd8, 0xeb,
+	0x5e, 0x89, 0x26, 0xa3, 0xb0, 0x2c, 0x4d, 0x9a, 0x02, 0x7f, 0x9e, 0xc0, 0xd1, 0xa7, 0x00, 0x38,
+	0xf4, 0xdd, 0xa8, 0xe7, 0xfa, 0xde, 0xe8, 0x16, 0xa5, 0x08, 0x87, 0xfe, 0x69, 0xef, 0x85, 0x37,
+	0x42, 0x3f, 0x03, 0xb8, 0x89, 0xe2, 0x2b, 0x57, 0xd6, 0xd3, 0xe5, 0xc9, 0x59, 0x17, 0x60, 0x79,
+	0x57, 0xa1, 0x1f, 0x43, 0x4d, 0xee, 0xc4, 0xa1, 0x6f, 0x96, 0x96, 0xed, 0xab, 0x0a, 0xe8, 0x51,
+	0xe8, 0xa3, 0x03, 0xd8, 0x18, 0x86, 0xf2, 0x94, 0xa2, 0x5e, 0xf7, 0x38, 0x8e, 0x97, 0xb7, 0xb3,
+	0xeb, 0xe3, 0x1d, 0x1d, 0xb1, 0xc1, 0x7e, 0x06, 0x5b, 0x2f, 0x31, 0xcf, 0x19, 0x70, 0xd1, 0x6d,
+	0x77, 0x04, 0xf7, 0xa6, 0xe1, 0xb2, 0xe4, 0x95, 0x63, 0xb1, 0x48, 0x6c, 0xad, 0x95, 0xb0, 0x3c,
+	0x56, 0xa1, 0x6c, 0x17, 0xb6, 0xdf, 0x52, 0xdf, 0xe3, 0x78, 0x4a, 0xf0, 0x6a, 0x3f, 0x9a, 0x1b,
+	0xcc, 0xaf, 0x60, 0x67, 0xa6, 0x80, 0xd5, 0x55, 0x0d, 0xa0, 0xfa, 0x65, 0x1c, 0xf5, 0x48, 0x80,
+	0xc7, 0x53, 0x61, 0x41, 0x9b, 0x0a, 0x1f, 0x41, 0x23, 0xba, 0x09, 0x5d, 0xaf, 0xdb, 0x8d, 0x86,
+	0x21, 0x4f, 0x92, 0x12, 0xa2, 0x9b, 0xb0, 0xa3, 0x28, 0xe8, 0x29, 0x54, 0x02, 0x99, 0xca, 0x66,
+	0x71, 0x41, 0x51, 0x49, 0x30, 0xa2, 0xf1, 0x10, 0x9d, 0x58, 0x22, 0x71, 0xa1, 0x2b, 0xbe, 0x86,
+	0x56, 0x1e, 0x2a, 0xcf, 0x56, 0xa3, 0xc9, 0x3a, 0xa9, 0x28, 0x9b, 0x99, 0xb8, 0x04, 0xe9, 0x8c,
+	0x21, 0xe2, 0xd7, 0x32, 0x6f, 0xc7, 0xd6, 0x53, 0x2b, 0xfb, 0x33, 0x68, 0x9d, 0xdd, 0x10, 0xde,
+	0xed, 0xa7, 0x5b, 0x54, 0x29, 0x99, 0x3a, 0xfc, 0x3c, 0xeb, 0xc7, 0xb0, 0x39, 0xb1, 0x9f, 0x51,
+	0xf4, 0x04, 0xaa, 0x89, 0xe0, 0xc4, 0xf2, 0x33, 0x54, 0x4b, 0x11, 0x7a, 0x65, 0x34, 0x6e, 0x55,
+	0x19, 0xed, 0x7f, 0x14, 0xa0, 0xf2, 0x42, 0x8a, 0x17, 0x6a, 0x31, 0x1c, 0x13, 0x2f, 0x48, 0x2d,
+	0xa6, 0x56, 0xe8, 0x21, 0xd4, 0xb3, 0x59, 0x4c, 0x79, 0x2a, 0x23, 0x88, 0xd2, 0x9a, 0xea, 0xa7,
+	0x0a, 0xf7, 0x58, 0x19, 0xbd, 0x04, 0x96, 0x56, 0x9b, 0x59, 0xcb, 0xfa, 0xcc, 0x6a, 0xb7, 0x60,
+	0x5d, 0xb8, 0x4c, 0xa9, 0x29, 0x9c, 0x6b, 0xff, 0x0a, 0x36, 0x72, 0x14, 0x46, 0xd1, 0x2e, 0x54,
+	0x95, 0x19, 0x53, 0x17, 0xb6, 0x32, 0x61, 0x0a, 0xe7, 0xa4, 0x00, 0xfb, 0x47, 0xb0, 0xa6, 0x48,
+	0xaf, 0xc3, 0x5e, 0xb4, 0x28, 0x58, 0xfe, 0x67, 0xc0, 0xba, 0x8e, 0x64, 0xf4, 0x8e, 0x56, 0xb2,
+	0xa1, 0x39, 0xf0, 0xc2, 0x61, 0xcf, 0xeb, 0xf2, 0x61, 0x8c, 0xd3, 0x36, 0x20, 0x47, 0x13, 0x3d,
+	0xc2, 0x20, 0xf2, 0x71, 0x90, 0xcc, 0x4d, 0x6a, 0x21, 0xda, 0x0b, 0x25, 0xc1, 0x4d, 0xba, 0x8f,
+	0xb2, 0xda, 0xaa, 0x88, 0x27, 0x92, 0x86, 0x9e, 0xc0, 0x66, 0xdf, 0x8b, 0xfd, 0x1b, 0x2f, 0xc6,
+	0xae, 0xb8, 0xa7, 0xc5, 0xc0, 0x2b, 0x87, 0xc4, 0xba, 0xd3, 0x4a, 0x19, 0x4e, 0x42, 0x17, 0xe0,
+	0x1e, 0x89, 0x07, 0x79, 0x70, 0x55, 0x81, 0x53, 0x86, 0x0e, 0x66, 0x51, 0x8f, 0xe7, 0xc1, 0x6a,
+	0xde, 0x6e, 0xa5, 0x8c, 0x31, 0xf8, 0x11, 0x34, 0xfa, 0x1e, 0x73, 0x2f, 0x3c, 0xce, 0x71, 0x3c,
+	0x32, 0xeb, 0x2a, 0xab, 0xfb, 0x1e, 0x3b, 0x50, 0x14, 0x71, 0x98, 0x84, 0xe9, 0x06, 0xf8, 0x1a,
+	0x07, 0x26, 0xc8, 0xc6, 0xa5, 0x99, 0x10, 0x8f, 0x05, 0xcd, 0x6e, 0x40, 0xfd, 0x15, 0xf6, 0x02,
+	0xde, 0x17, 0x9e, 0xfe, 0xae, 0x08, 0x4d, 0xe5, 0x01, 0x45, 0xbb, 0xa3, 0xfd, 0x11, 0x94, 0x62,
+	0xc6, 0x48, 0x32, 0x9f, 0xcb, 0x6f, 0x74, 0x04, 0xad, 0xc0, 0x63, 0xdc, 0x8d, 0x62, 0x82, 0x43,
+	0x9e, 0x3d, 0x44, 0x2d, 0xbe, 0x50, 0x37, 0xc4, 0x9e, 0xd3, 0x6c, 0x8b, 0xec, 0xff, 0x28, 0x71,
+	0x63, 0xec, 0x75, 0xfb, 0xde, 0xc5, 0x38, 0x78, 0x9b, 0x1e, 0x25, 0x4e, 0x4a, 0x13, 0x96, 0xe1,
+	0xd1, 0x15, 0x0e, 0xdd, 0x6b, 0x2f, 0x20, 0xbe, 0x74, 0x4d, 0xcd, 0x01, 0x49, 0xfa, 0x9d, 0xa0,
+	0xa0, 0x07, 0x50, 0x17, 0x7f, 0xc1, 0x71, 0x1c, 0xc5, 0x89, 0x33, 0x6a, 0x1e, 0x25, 0x47, 0x62,
+	0x8d, 0x7e, 0x01, 0x0d, 0xc1, 0x0c, 0x3c, 0x8e, 0xc3, 0xee, 0xc8, 0xac, 0x2d, 0xbb, 0xad, 0xc0,
+	0xa3, 0xe4, 0x58, 0x81, 0xf5, 0xfc, 0xac, 0xe7, 0xf3, 0xf3, 0x43, 0x58, 0x53, 0x3a, 0x75, 0xfb,
+	0x58, 0xdc, 0x6c, 0xd2, 0x19, 0x35, 0xa7, 0x29, 0x89, 0x87, 0x8a, 0x26, 0x14, 0xff, 0x66, 0x88,
+	0x87, 0xd8, 0xf5, 0x31, 0xe5, 0x7d, 0xb3, 0xa1, 0x9e, 0xe1, 0x24, 0xe9, 0x85, 0xa0, 0xd8, 0x7f,
+	0x17, 0x83, 0x44, 0xe2, 0x2e, 0x46, 0x85, 0xb8, 0x6b, 0x1c, 0xcb, 0x28, 0x51, 0xfe, 0x49, 0x97,
+	0x13, 0xf3, 0x95, 0xb1, 0xca, 0x7c, 0xf5, 0x49, 0x96, 0xdb, 0xc5, 0xc9, 0xb2, 0xa6, 0x07, 0xc7,
+	0x38, 0xc3, 0x77, 0x9f, 0x01, 0x64, 0xaf, 0x34, 0x68, 0x1d, 0xe0, 0x8b, 0xd3, 0xc3, 0xb7, 0x67,
+	0xee, 0xef, 0x4f, 0x9d, 0x37, 0xad, 0xf7, 0xd0, 0x06, 0x34, 0xd4, 0xfa, 0xc0, 0x39, 0xea, 0xbc,
+	0x69, 0x15, 0x76, 0x7f, 0x03, 0xad, 0xc9, 0x0e, 0x1b, 0x6d, 0xc2, 0xda, 0x71, 0xe7, 0xeb, 0xd3,
+	0xb7, 0xe7, 0x6e, 0xe7, 0xec, 0xec, 0xf5, 0xcb, 0x93, 0xd6, 0x7b, 0x1a, 0xe9, 0xd0, 0x39, 0xea,
+	0x9c, 0x1f, 0xb5, 0x0a, 0x68, 0x0b, 0x36, 0x12, 0xd2, 0xdb, 0x93, 0x04, 0x67, 0xec, 0xff, 0xb9,
+	0x0e, 0xc5, 0x77, 0x98, 0xa0, 0x37, 0xaa, 0x70, 0x65, 0xaf, 0x8e, 0xe8, 0x81, 0x56, 0x8f, 0x27,
+	0x1f, 0x2f, 0xad, 0x87, 0xf3, 0x99, 0x8c, 0xa2, 0x13, 0xd8, 0x98, 0x78, 0x19, 0x44, 0xda, 0x86,
+	0xe9, 0x37, 0x46, 0xeb, 0xfb, 0x0b, 0xb8, 0x8c, 0x0a, 0xe5, 0xf2, 0xef, 0x31, 0xba, 0x72, 0x53,
+	0x0f, 0x46, 0xd6, 0xc3, 0xf9, 0x4c, 0x46, 0xd1, 0x2f, 0xa1, 0x3e, 0x7e, 0x0a, 0x41, 0xdb, 0xf9,
+	0x73, 0xa4, 0xef, 0x28, 0xd6, 0xce, 0x4c, 0x3a, 0xa3, 0xe8, 0x00, 0x1a, 0x5a, 0x8f, 0x8f, 0x4c,
+	0x4d, 0x54, 0x6e, 0xd2, 0xb0, 0xee, 0xcf, 0xe1, 0x30, 0x8a, 0x5e, 0xc1, 0x5a, 0xee, 0x01, 0x03,
+	0x69, 0xd3, 0xd3, 0xe4, 0x5b, 0x8a, 0xf5, 0x60, 0x2e, 0x8f, 0x51, 0x74, 0x04, 0x4d, 0xfd, 0x69,
+	0x02, 0xdd, 0xd7, 0xc1, 0xb9, 0xd7, 0x0d, 0xcb, 0x9a, 0xc7, 0x62, 0x14, 0xfd, 0x14, 0xaa, 0xc9,
+	0x74, 0x8b, 0xb4, 0xe6, 0x25, 0x9b, 0xb9, 0xad, 0xef, 0xcd, 0xa0, 0x2a, 0xbf, 0xe4, 0xe7, 0x09,
+	0xdd, 0x2f, 0x53, 0xb3, 0x8a, 0xf5, 0x70, 0x3e, 0x93, 0x51, 0xf4, 0x5b, 0x68, 0x4d, 0x36, 0x9e,
+	0x48, 0x8b, 0x8b, 0x19, 0x3d, 0xac, 0xf5, 0xfe, 0x22, 0x36, 0xa3, 0xe8, 0x1d, 0x6c, 0xcd, 0xe8,
+	0x11, 0xd1, 0xe3, 0x6c, 0xdb, 0xec, 0x1e, 0xd5, 0xfa, 0x60, 0x09, 0x42, 0x99, 0x5e, 0x6f, 0xce,
+	0x74, 0xd3, 0x4f, 0xf4, 0x77, 0x96, 0x35, 0x8f, 0x95, 0xc4, 0x82, 0xde, 0x48, 0xe5, 0x62, 0x61,
+	0xa2, 0x43, 0xb3, 0x1e, 0xcc, 0xe5, 0xa9, 0xc8, 0xd4, 0x1a, 0x0d, 0x3d, 0x32, 0xf3, 0x1d, 0x89,
+	0x75, 0x7f, 0x0e, 0x87, 0x51, 0xf4, 0x39, 0x40, 0xd6, 0x43, 0xa0, 0x9d, 0xc9, 0xd2, 0x95, 0xf4,
+	0x20, 0x96, 0x39, 0x9b, 0xc1, 0x28, 0x7a, 0x0e, 0x95, 0xe4, 0xf2, 0xd3, 0x5e, 0x44, 0xc6, 0x57,
+	0xa4, 0x75, 0x6f, 0x9a, 0xc8, 0xe8, 0x41, 0xe5, 0x5d, 0x49, 0x90, 0x2e, 0x2a, 0xb2, 0xb4, 0x3e,
+	0xff, 0xff, 0x00, 0x7c, 0xf1, 0xb8, 0x23, 0x1c, 0x1a, 0x00, 0x00,
 }
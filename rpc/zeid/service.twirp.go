@@ -32,12 +32,41 @@ import url "net/url"
 // Zei Interface
 // =============
 
+// Zei tracks time with the ZEI devices managed by zeid. Requests address a
+// device by its serial number in their device field, it may be left empty
+// while a single device is connected.
 type Zei interface {
 	ListActivities(context.Context, *ListActivitiesReq) (*ListActivitiesResp, error)
 
 	CurrentActivity(context.Context, *CurrentActivityReq) (*CurrentActivityResp, error)
 
 	AssignActivity(context.Context, *AssignActivityReq) (*AssignActivityResp, error)
+
+	ListSides(context.Context, *ListSidesReq) (*ListSidesResp, error)
+
+	ApplyLayout(context.Context, *ApplyLayoutReq) (*ApplyLayoutResp, error)
+
+	StartActivity(context.Context, *StartActivityReq) (*StartActivityResp, error)
+
+	StopActivity(context.Context, *StopActivityReq) (*StopActivityResp, error)
+
+	History(context.Context, *HistoryReq) (*HistoryResp, error)
+
+	ResolveIdleGap(context.Context, *ResolveIdleGapReq) (*ResolveIdleGapResp, error)
+
+	GetReminderRules(context.Context, *GetReminderRulesReq) (*GetReminderRulesResp, error)
+
+	UpdateReminderRules(context.Context, *UpdateReminderRulesReq) (*UpdateReminderRulesResp, error)
+
+	ListProfiles(context.Context, *ListProfilesReq) (*ListProfilesResp, error)
+
+	SwitchProfile(context.Context, *SwitchProfileReq) (*SwitchProfileResp, error)
+
+	ListDevices(context.Context, *ListDevicesReq) (*ListDevicesResp, error)
+
+	DeviceInfo(context.Context, *DeviceInfoReq) (*DeviceInfoResp, error)
+
+	Health(context.Context, *HealthReq) (*HealthResp, error)
 }
 
 // ===================
@@ -46,17 +75,30 @@ type Zei interface {
 
 type zeiProtobufClient struct {
 	client HTTPClient
-	urls   [3]string
+	urls   [16]string
 }
 
 // NewZeiProtobufClient creates a Protobuf client that implements the Zei interface.
 // It communicates using Protobuf and can be configured with a custom HTTPClient.
 func NewZeiProtobufClient(addr string, client HTTPClient) Zei {
 	prefix := urlBase(addr) + ZeiPathPrefix
-	urls := [3]string{
+	urls := [16]string{
 		prefix + "ListActivities",
 		prefix + "CurrentActivity",
 		prefix + "AssignActivity",
+		prefix + "ListSides",
+		prefix + "ApplyLayout",
+		prefix + "StartActivity",
+		prefix + "StopActivity",
+		prefix + "History",
+		prefix + "ResolveIdleGap",
+		prefix + "GetReminderRules",
+		prefix + "UpdateReminderRules",
+		prefix + "ListProfiles",
+		prefix + "SwitchProfile",
+		prefix + "ListDevices",
+		prefix + "DeviceInfo",
+		prefix + "Health",
 	}
 	if httpClient, ok := client.(*http.Client); ok {
 		return &zeiProtobufClient{
@@ -97,23 +139,153 @@ func (c *zeiProtobufClient) AssignActivity(ctx context.Context, in *AssignActivi
 	return out, err
 }
 
+func (c *zeiProtobufClient) ListSides(ctx context.Context, in *ListSidesReq) (*ListSidesResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "ListSides")
+	out := new(ListSidesResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[3], in, out)
+	return out, err
+}
+
+func (c *zeiProtobufClient) ApplyLayout(ctx context.Context, in *ApplyLayoutReq) (*ApplyLayoutResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "ApplyLayout")
+	out := new(ApplyLayoutResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[4], in, out)
+	return out, err
+}
+
+func (c *zeiProtobufClient) StartActivity(ctx context.Context, in *StartActivityReq) (*StartActivityResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "StartActivity")
+	out := new(StartActivityResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[5], in, out)
+	return out, err
+}
+
+func (c *zeiProtobufClient) StopActivity(ctx context.Context, in *StopActivityReq) (*StopActivityResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "StopActivity")
+	out := new(StopActivityResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[6], in, out)
+	return out, err
+}
+
+func (c *zeiProtobufClient) History(ctx context.Context, in *HistoryReq) (*HistoryResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "History")
+	out := new(HistoryResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[7], in, out)
+	return out, err
+}
+
+func (c *zeiProtobufClient) ResolveIdleGap(ctx context.Context, in *ResolveIdleGapReq) (*ResolveIdleGapResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "ResolveIdleGap")
+	out := new(ResolveIdleGapResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[8], in, out)
+	return out, err
+}
+
+func (c *zeiProtobufClient) GetReminderRules(ctx context.Context, in *GetReminderRulesReq) (*GetReminderRulesResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "GetReminderRules")
+	out := new(GetReminderRulesResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[9], in, out)
+	return out, err
+}
+
+func (c *zeiProtobufClient) UpdateReminderRules(ctx context.Context, in *UpdateReminderRulesReq) (*UpdateReminderRulesResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "UpdateReminderRules")
+	out := new(UpdateReminderRulesResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[10], in, out)
+	return out, err
+}
+
+func (c *zeiProtobufClient) ListProfiles(ctx context.Context, in *ListProfilesReq) (*ListProfilesResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "ListProfiles")
+	out := new(ListProfilesResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[11], in, out)
+	return out, err
+}
+
+func (c *zeiProtobufClient) SwitchProfile(ctx context.Context, in *SwitchProfileReq) (*SwitchProfileResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "SwitchProfile")
+	out := new(SwitchProfileResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[12], in, out)
+	return out, err
+}
+
+func (c *zeiProtobufClient) ListDevices(ctx context.Context, in *ListDevicesReq) (*ListDevicesResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "ListDevices")
+	out := new(ListDevicesResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[13], in, out)
+	return out, err
+}
+
+func (c *zeiProtobufClient) DeviceInfo(ctx context.Context, in *DeviceInfoReq) (*DeviceInfoResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "DeviceInfo")
+	out := new(DeviceInfoResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[14], in, out)
+	return out, err
+}
+
+func (c *zeiProtobufClient) Health(ctx context.Context, in *HealthReq) (*HealthResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "Health")
+	out := new(HealthResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[15], in, out)
+	return out, err
+}
+
 // ===============
 // Zei JSON Client
 // ===============
 
 type zeiJSONClient struct {
 	client HTTPClient
-	urls   [3]string
+	urls   [16]string
 }
 
 // NewZeiJSONClient creates a JSON client that implements the Zei interface.
 // It communicates using JSON and can be configured with a custom HTTPClient.
 func NewZeiJSONClient(addr string, client HTTPClient) Zei {
 	prefix := urlBase(addr) + ZeiPathPrefix
-	urls := [3]string{
+	urls := [16]string{
 		prefix + "ListActivities",
 		prefix + "CurrentActivity",
 		prefix + "AssignActivity",
+		prefix + "ListSides",
+		prefix + "ApplyLayout",
+		prefix + "StartActivity",
+		prefix + "StopActivity",
+		prefix + "History",
+		prefix + "ResolveIdleGap",
+		prefix + "GetReminderRules",
+		prefix + "UpdateReminderRules",
+		prefix + "ListProfiles",
+		prefix + "SwitchProfile",
+		prefix + "ListDevices",
+		prefix + "DeviceInfo",
+		prefix + "Health",
 	}
 	if httpClient, ok := client.(*http.Client); ok {
 		return &zeiJSONClient{
@@ -154,6 +326,123 @@ func (c *zeiJSONClient) AssignActivity(ctx context.Context, in *AssignActivityRe
 	return out, err
 }
 
+func (c *zeiJSONClient) ListSides(ctx context.Context, in *ListSidesReq) (*ListSidesResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "ListSides")
+	out := new(ListSidesResp)
+	err := doJSONRequest(ctx, c.client, c.urls[3], in, out)
+	return out, err
+}
+
+func (c *zeiJSONClient) ApplyLayout(ctx context.Context, in *ApplyLayoutReq) (*ApplyLayoutResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "ApplyLayout")
+	out := new(ApplyLayoutResp)
+	err := doJSONRequest(ctx, c.client, c.urls[4], in, out)
+	return out, err
+}
+
+func (c *zeiJSONClient) StartActivity(ctx context.Context, in *StartActivityReq) (*StartActivityResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "StartActivity")
+	out := new(StartActivityResp)
+	err := doJSONRequest(ctx, c.client, c.urls[5], in, out)
+	return out, err
+}
+
+func (c *zeiJSONClient) StopActivity(ctx context.Context, in *StopActivityReq) (*StopActivityResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "StopActivity")
+	out := new(StopActivityResp)
+	err := doJSONRequest(ctx, c.client, c.urls[6], in, out)
+	return out, err
+}
+
+func (c *zeiJSONClient) History(ctx context.Context, in *HistoryReq) (*HistoryResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "History")
+	out := new(HistoryResp)
+	err := doJSONRequest(ctx, c.client, c.urls[7], in, out)
+	return out, err
+}
+
+func (c *zeiJSONClient) ResolveIdleGap(ctx context.Context, in *ResolveIdleGapReq) (*ResolveIdleGapResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "ResolveIdleGap")
+	out := new(ResolveIdleGapResp)
+	err := doJSONRequest(ctx, c.client, c.urls[8], in, out)
+	return out, err
+}
+
+func (c *zeiJSONClient) GetReminderRules(ctx context.Context, in *GetReminderRulesReq) (*GetReminderRulesResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "GetReminderRules")
+	out := new(GetReminderRulesResp)
+	err := doJSONRequest(ctx, c.client, c.urls[9], in, out)
+	return out, err
+}
+
+func (c *zeiJSONClient) UpdateReminderRules(ctx context.Context, in *UpdateReminderRulesReq) (*UpdateReminderRulesResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "UpdateReminderRules")
+	out := new(UpdateReminderRulesResp)
+	err := doJSONRequest(ctx, c.client, c.urls[10], in, out)
+	return out, err
+}
+
+func (c *zeiJSONClient) ListProfiles(ctx context.Context, in *ListProfilesReq) (*ListProfilesResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "ListProfiles")
+	out := new(ListProfilesResp)
+	err := doJSONRequest(ctx, c.client, c.urls[11], in, out)
+	return out, err
+}
+
+func (c *zeiJSONClient) SwitchProfile(ctx context.Context, in *SwitchProfileReq) (*SwitchProfileResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "SwitchProfile")
+	out := new(SwitchProfileResp)
+	err := doJSONRequest(ctx, c.client, c.urls[12], in, out)
+	return out, err
+}
+
+func (c *zeiJSONClient) ListDevices(ctx context.Context, in *ListDevicesReq) (*ListDevicesResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "ListDevices")
+	out := new(ListDevicesResp)
+	err := doJSONRequest(ctx, c.client, c.urls[13], in, out)
+	return out, err
+}
+
+func (c *zeiJSONClient) DeviceInfo(ctx context.Context, in *DeviceInfoReq) (*DeviceInfoResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "DeviceInfo")
+	out := new(DeviceInfoResp)
+	err := doJSONRequest(ctx, c.client, c.urls[14], in, out)
+	return out, err
+}
+
+func (c *zeiJSONClient) Health(ctx context.Context, in *HealthReq) (*HealthResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "zei.zeid")
+	ctx = ctxsetters.WithServiceName(ctx, "Zei")
+	ctx = ctxsetters.WithMethodName(ctx, "Health")
+	out := new(HealthResp)
+	err := doJSONRequest(ctx, c.client, c.urls[15], in, out)
+	return out, err
+}
+
 // ==================
 // Zei Server Handler
 // ==================
@@ -211,6 +500,45 @@ func (s *zeiServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	case "/twirp/zei.zeid.Zei/AssignActivity":
 		s.serveAssignActivity(ctx, resp, req)
 		return
+	case "/twirp/zei.zeid.Zei/ListSides":
+		s.serveListSides(ctx, resp, req)
+		return
+	case "/twirp/zei.zeid.Zei/ApplyLayout":
+		s.serveApplyLayout(ctx, resp, req)
+		return
+	case "/twirp/zei.zeid.Zei/StartActivity":
+		s.serveStartActivity(ctx, resp, req)
+		return
+	case "/twirp/zei.zeid.Zei/StopActivity":
+		s.serveStopActivity(ctx, resp, req)
+		return
+	case "/twirp/zei.zeid.Zei/History":
+		s.serveHistory(ctx, resp, req)
+		return
+	case "/twirp/zei.zeid.Zei/ResolveIdleGap":
+		s.serveResolveIdleGap(ctx, resp, req)
+		return
+	case "/twirp/zei.zeid.Zei/GetReminderRules":
+		s.serveGetReminderRules(ctx, resp, req)
+		return
+	case "/twirp/zei.zeid.Zei/UpdateReminderRules":
+		s.serveUpdateReminderRules(ctx, resp, req)
+		return
+	case "/twirp/zei.zeid.Zei/ListProfiles":
+		s.serveListProfiles(ctx, resp, req)
+		return
+	case "/twirp/zei.zeid.Zei/SwitchProfile":
+		s.serveSwitchProfile(ctx, resp, req)
+		return
+	case "/twirp/zei.zeid.Zei/ListDevices":
+		s.serveListDevices(ctx, resp, req)
+		return
+	case "/twirp/zei.zeid.Zei/DeviceInfo":
+		s.serveDeviceInfo(ctx, resp, req)
+		return
+	case "/twirp/zei.zeid.Zei/Health":
+		s.serveHealth(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		err = badRouteError(msg, req.Method, req.URL.Path)
@@ -651,6 +979,1878 @@ func (s *zeiServer) serveAssignActivityProtobuf(ctx context.Context, resp http.R
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *zeiServer) serveListSides(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListSidesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListSidesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *zeiServer) serveListSidesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListSides")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ListSidesReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListSidesResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListSides(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListSidesResp and nil error while calling ListSides. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveListSidesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListSides")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(ListSidesReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListSidesResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListSides(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListSidesResp and nil error while calling ListSides. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveApplyLayout(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveApplyLayoutJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveApplyLayoutProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *zeiServer) serveApplyLayoutJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ApplyLayout")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ApplyLayoutReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ApplyLayoutResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ApplyLayout(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ApplyLayoutResp and nil error while calling ApplyLayout. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveApplyLayoutProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ApplyLayout")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(ApplyLayoutReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ApplyLayoutResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ApplyLayout(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ApplyLayoutResp and nil error while calling ApplyLayout. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveStartActivity(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveStartActivityJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveStartActivityProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *zeiServer) serveStartActivityJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "StartActivity")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(StartActivityReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *StartActivityResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.StartActivity(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *StartActivityResp and nil error while calling StartActivity. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveStartActivityProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "StartActivity")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(StartActivityReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *StartActivityResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.StartActivity(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *StartActivityResp and nil error while calling StartActivity. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveStopActivity(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveStopActivityJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveStopActivityProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *zeiServer) serveStopActivityJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "StopActivity")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(StopActivityReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *StopActivityResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.StopActivity(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *StopActivityResp and nil error while calling StopActivity. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveStopActivityProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "StopActivity")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(StopActivityReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *StopActivityResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.StopActivity(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *StopActivityResp and nil error while calling StopActivity. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveHistory(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveHistoryJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveHistoryProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *zeiServer) serveHistoryJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "History")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(HistoryReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *HistoryResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.History(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *HistoryResp and nil error while calling History. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveHistoryProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "History")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(HistoryReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *HistoryResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.History(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *HistoryResp and nil error while calling History. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveResolveIdleGap(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveResolveIdleGapJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveResolveIdleGapProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *zeiServer) serveResolveIdleGapJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ResolveIdleGap")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ResolveIdleGapReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ResolveIdleGapResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ResolveIdleGap(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ResolveIdleGapResp and nil error while calling ResolveIdleGap. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveResolveIdleGapProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ResolveIdleGap")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(ResolveIdleGapReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ResolveIdleGapResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ResolveIdleGap(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ResolveIdleGapResp and nil error while calling ResolveIdleGap. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveGetReminderRules(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetReminderRulesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetReminderRulesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *zeiServer) serveGetReminderRulesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetReminderRules")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GetReminderRulesReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GetReminderRulesResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.GetReminderRules(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetReminderRulesResp and nil error while calling GetReminderRules. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveGetReminderRulesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetReminderRules")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(GetReminderRulesReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GetReminderRulesResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.GetReminderRules(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetReminderRulesResp and nil error while calling GetReminderRules. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveUpdateReminderRules(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUpdateReminderRulesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUpdateReminderRulesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *zeiServer) serveUpdateReminderRulesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UpdateReminderRules")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(UpdateReminderRulesReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *UpdateReminderRulesResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.UpdateReminderRules(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpdateReminderRulesResp and nil error while calling UpdateReminderRules. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveUpdateReminderRulesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UpdateReminderRules")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(UpdateReminderRulesReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *UpdateReminderRulesResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.UpdateReminderRules(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpdateReminderRulesResp and nil error while calling UpdateReminderRules. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveListProfiles(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListProfilesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListProfilesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *zeiServer) serveListProfilesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListProfiles")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ListProfilesReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListProfilesResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListProfiles(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListProfilesResp and nil error while calling ListProfiles. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveListProfilesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListProfiles")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(ListProfilesReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListProfilesResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListProfiles(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListProfilesResp and nil error while calling ListProfiles. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveSwitchProfile(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSwitchProfileJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSwitchProfileProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *zeiServer) serveSwitchProfileJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SwitchProfile")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(SwitchProfileReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *SwitchProfileResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.SwitchProfile(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SwitchProfileResp and nil error while calling SwitchProfile. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveSwitchProfileProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SwitchProfile")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(SwitchProfileReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *SwitchProfileResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.SwitchProfile(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SwitchProfileResp and nil error while calling SwitchProfile. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveListDevices(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListDevicesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListDevicesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *zeiServer) serveListDevicesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListDevices")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ListDevicesReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListDevicesResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListDevices(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListDevicesResp and nil error while calling ListDevices. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveListDevicesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListDevices")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(ListDevicesReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListDevicesResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListDevices(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListDevicesResp and nil error while calling ListDevices. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveDeviceInfo(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDeviceInfoJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDeviceInfoProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *zeiServer) serveDeviceInfoJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeviceInfo")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(DeviceInfoReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *DeviceInfoResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.DeviceInfo(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeviceInfoResp and nil error while calling DeviceInfo. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveDeviceInfoProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeviceInfo")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(DeviceInfoReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *DeviceInfoResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.DeviceInfo(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeviceInfoResp and nil error while calling DeviceInfo. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveHealth(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveHealthJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveHealthProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *zeiServer) serveHealthJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Health")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(HealthReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *HealthResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Health(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *HealthResp and nil error while calling Health. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *zeiServer) serveHealthProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Health")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(HealthReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *HealthResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Health(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *HealthResp and nil error while calling Health. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *zeiServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor0, 0
 }
@@ -1080,29 +3280,136 @@ func callError(ctx context.Context, h *twirp.ServerHooks, err twirp.Error) conte
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 378 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xc1, 0x6e, 0xda, 0x40,
-	0x10, 0x86, 0x65, 0x0c, 0xd4, 0x0c, 0x12, 0x15, 0x03, 0x6a, 0x2d, 0x5a, 0x54, 0xcb, 0x27, 0x4e,
-	0x46, 0xa2, 0x7d, 0x01, 0xda, 0x13, 0x6a, 0xd5, 0x83, 0xdb, 0x13, 0x17, 0xcb, 0xf1, 0x8e, 0xd0,
-	0x48, 0x60, 0x3b, 0xbb, 0x1b, 0x14, 0x90, 0x72, 0xce, 0xe3, 0xe6, 0x15, 0x22, 0x2f, 0x36, 0x31,
-	0x38, 0xc9, 0xcd, 0xfe, 0x66, 0xc7, 0xfa, 0xfe, 0x7f, 0x65, 0xf8, 0x24, 0xf3, 0x64, 0x7e, 0x24,
-	0x16, 0x73, 0x45, 0x72, 0xcf, 0x09, 0x05, 0xb9, 0xcc, 0x74, 0x86, 0xce, 0x91, 0x38, 0x28, 0xb8,
-	0xff, 0x68, 0x81, 0xb3, 0x4c, 0x34, 0xef, 0x59, 0x1f, 0x70, 0x00, 0x2d, 0x16, 0xae, 0xe5, 0x59,
-	0xb3, 0x5e, 0xd8, 0x62, 0x81, 0x08, 0xed, 0x34, 0xde, 0x91, 0xdb, 0x32, 0xc4, 0x3c, 0xe3, 0x18,
-	0x3a, 0x49, 0xb6, 0xcd, 0xa4, 0x6b, 0x1b, 0x78, 0x7a, 0x41, 0x0f, 0xfa, 0x9c, 0x6a, 0xda, 0xc8,
-	0x58, 0x73, 0x96, 0xba, 0x6d, 0x33, 0xab, 0x23, 0xfc, 0x06, 0x7d, 0x41, 0x85, 0x42, 0xa4, 0x58,
-	0x90, 0xdb, 0xf1, 0xac, 0x99, 0x1d, 0xc2, 0x09, 0xfd, 0x63, 0x41, 0xfe, 0x08, 0x86, 0x7f, 0x58,
-	0xe9, 0x52, 0x86, 0x49, 0x85, 0x74, 0xeb, 0xdf, 0x03, 0x5e, 0x43, 0x95, 0xe3, 0x02, 0x20, 0x3e,
-	0x13, 0xd7, 0xf2, 0xec, 0x59, 0x7f, 0x81, 0x41, 0x95, 0x29, 0xa8, 0xf2, 0x84, 0xb5, 0x53, 0x18,
-	0xc0, 0x28, 0xb9, 0x93, 0x92, 0x52, 0x1d, 0x95, 0xf4, 0x10, 0xb1, 0x28, 0xa3, 0x0d, 0xcb, 0x51,
-	0xb5, 0xb9, 0x12, 0xfe, 0x18, 0xf0, 0xd7, 0x25, 0x2c, 0x7c, 0x1e, 0x60, 0xd4, 0xa0, 0x2a, 0xc7,
-	0x00, 0x9c, 0xea, 0xa3, 0xa6, 0xbe, 0xd7, 0x75, 0xce, 0x67, 0x70, 0x0a, 0xa0, 0x74, 0x2c, 0x75,
-	0xa4, 0xf9, 0x5c, 0x6f, 0xcf, 0x90, 0xff, 0xbc, 0x23, 0xfc, 0x0c, 0x1f, 0x58, 0x45, 0x2c, 0xb6,
-	0x64, 0x5a, 0x76, 0xc2, 0x2e, 0xab, 0x95, 0xd8, 0x92, 0xff, 0x03, 0x86, 0x4b, 0xa5, 0x78, 0x93,
-	0xd6, 0x9c, 0x8a, 0x66, 0xeb, 0x89, 0x4e, 0xd7, 0x57, 0x45, 0x2f, 0xa3, 0x5c, 0x6f, 0xa9, 0x7c,
-	0xf1, 0x64, 0x81, 0xbd, 0x26, 0xc6, 0xdf, 0x30, 0xb8, 0xac, 0x18, 0xbf, 0xbc, 0xb8, 0x37, 0x6e,
-	0x64, 0xf2, 0xf5, 0xed, 0xa1, 0xca, 0xf1, 0x2f, 0x7c, 0xbc, 0xea, 0x07, 0x6b, 0x0b, 0xcd, 0x42,
-	0x27, 0xd3, 0x77, 0xa6, 0x2a, 0x2f, 0xe4, 0x2e, 0xd5, 0xeb, 0x72, 0x8d, 0x2a, 0xea, 0x72, 0xcd,
-	0xc4, 0x3f, 0xbb, 0xeb, 0x76, 0x31, 0xba, 0xe9, 0x9a, 0x9f, 0xe0, 0xfb, 0x73, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x29, 0x60, 0x9b, 0x77, 0x1e, 0x03, 0x00, 0x00,
+	// 2091 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0x5f, 0x73, 0xe4, 0x46,
+	0x11, 0xcf, 0x6a, 0xff, 0xf7, 0xae, 0xed, 0xf5, 0xf8, 0xb0, 0x75, 0xba, 0x23, 0x77, 0x51, 0x28,
+	0x48, 0x7c, 0x77, 0xeb, 0x94, 0x0f, 0x08, 0x50, 0x90, 0xd4, 0xda, 0xe7, 0xdc, 0x1d, 0x67, 0xec,
+	0x20, 0xfb, 0x20, 0xb9, 0x2a, 0x4a, 0x25, 0xaf, 0x66, 0xbd, 0x53, 0xd6, 0x4a, 0x13, 0xcd, 0xac,
+	0xcd, 0xe6, 0x09, 0x1e, 0x80, 0x0f, 0xc0, 0x7b, 0xaa, 0xf8, 0x0a, 0x14, 0x8f, 0xbc, 0xf0, 0xc4,
+	0xf7, 0xe1, 0x13, 0x50, 0x33, 0x23, 0xad, 0x46, 0xfb, 0xd7, 0xeb, 0x37, 0x4d, 0xf7, 0x6f, 0xd4,
+	0x3d, 0xfd, 0x6f, 0xba, 0x07, 0xb6, 0x63, 0xda, 0xdd, 0xfb, 0x16, 0x13, 0x7f, 0x8f, 0xe1, 0xf8,
+	0x9a, 0x74, 0x71, 0x9b, 0xc6, 0x11, 0x8f, 0x50, 0xed, 0x5b, 0x4c, 0xda, 0x82, 0x6e, 0xbd, 0x7f,
+	0x19, 0x45, 0x97, 0x01, 0xde, 0x93, 0xf4, 0x8b, 0x61, 0x6f, 0xcf, 0x1f, 0xc6, 0x1e, 0x27, 0x51,
+	0xa8, 0x90, 0xd6, 0xa3, 0x49, 0x3e, 0x27, 0x03, 0xcc, 0xb8, 0x37, 0xa0, 0x0a, 0x60, 0xff, 0xad,
+	0x00, 0xb5, 0x4e, 0x97, 0x93, 0x6b, 0xc2, 0x47, 0x68, 0x1d, 0x0c, 0xe2, 0x9b, 0x85, 0xc7, 0x85,
+	0x8f, 0xea, 0x8e, 0x41, 0x7c, 0x84, 0xa0, 0x14, 0x7a, 0x03, 0x6c, 0x1a, 0x92, 0x22, 0xbf, 0xd1,
+	0x3d, 0x28, 0x77, 0xa3, 0x20, 0x8a, 0xcd, 0xa2, 0x24, 0xaa, 0x05, 0x7a, 0x0c, 0x0d, 0x12, 0x72,
+	0x7c, 0xa9, 0x84, 0x9b, 0x25, 0xc9, 0xd3, 0x49, 0xe8, 0x11, 0x34, 0x7c, 0x2c, 0xce, 0xe0, 0x32,
+	0xe2, 0x63, 0xb3, 0xfc, 0xb8, 0xf0, 0x51, 0xd1, 0x01, 0x45, 0x3a, 0x23, 0x3e, 0xb6, 0x9f, 0xc0,
+	0xe6, 0x31, 0x61, 0x3c, 0x51, 0x86, 0x60, 0xe6, 0xe0, 0x6f, 0xd0, 0x36, 0x54, 0x14, 0x24, 0xd1,
+	0x2a, 0x59, 0xd9, 0x7f, 0x04, 0x34, 0x09, 0x66, 0x14, 0xed, 0x03, 0x78, 0x63, 0x8a, 0x59, 0x78,
+	0x5c, 0xfc, 0xa8, 0xb1, 0x8f, 0xda, 0xa9, 0xb1, 0xda, 0xe9, 0x39, 0x1d, 0x0d, 0x85, 0xda, 0xb0,
+	0xd5, 0x1d, 0xc6, 0x31, 0x0e, 0xb9, 0x9b, 0x50, 0x47, 0x2e, 0xf1, 0x93, 0x23, 0x6f, 0x26, 0xac,
+	0x74, 0xe7, 0x6b, 0xdf, 0x7e, 0x0a, 0xe8, 0x30, 0x4f, 0x5c, 0xa4, 0xe7, 0x7f, 0x0d, 0xd8, 0x9a,
+	0x82, 0x33, 0x8a, 0xda, 0x50, 0x4b, 0xa5, 0xc9, 0x1d, 0xb3, 0xf5, 0x1c, 0x63, 0xd0, 0x0e, 0x54,
+	0x09, 0x73, 0x89, 0x1f, 0x60, 0x69, 0xf7, 0x9a, 0x53, 0x21, 0xec, 0xb5, 0x1f, 0x60, 0xf4, 0x73,
+	0x00, 0xc6, 0xbd, 0x98, 0xbb, 0xc2, 0xb1, 0xd2, 0xee, 0x8d, 0x7d, 0xab, 0xad, 0xbc, 0xde, 0x4e,
+	0xbd, 0xde, 0x3e, 0x4f, 0xbd, 0xee, 0xd4, 0x25, 0x5a, 0xac, 0xd1, 0x4f, 0xa0, 0x96, 0x46, 0x8b,
+	0x74, 0x47, 0x63, 0xff, 0xfe, 0xd4, 0xc6, 0x17, 0x09, 0xc0, 0x19, 0x43, 0xd1, 0x53, 0x28, 0xf7,
+	0xa2, 0xee, 0x90, 0x99, 0x15, 0xb9, 0x67, 0x3b, 0xd3, 0xfb, 0x0b, 0x41, 0x3e, 0xc3, 0x8c, 0x89,
+	0x0d, 0x0a, 0x84, 0x3e, 0x86, 0x56, 0xe2, 0xf6, 0x6e, 0x14, 0x86, 0xb8, 0xcb, 0xb1, 0x6f, 0x56,
+	0xe5, 0x09, 0x36, 0x14, 0xfd, 0x30, 0x25, 0x6b, 0x36, 0xac, 0xe9, 0x36, 0xfc, 0x75, 0xa9, 0x66,
+	0xb4, 0x8a, 0xf6, 0x5f, 0x0d, 0x68, 0xea, 0x02, 0x56, 0x36, 0xe1, 0x2e, 0x94, 0x69, 0xdf, 0x63,
+	0x2a, 0x9a, 0xd7, 0xf7, 0xef, 0x4d, 0xe8, 0xfd, 0xa5, 0xe0, 0x39, 0x0a, 0x22, 0x83, 0x7c, 0xd4,
+	0x4d, 0x8c, 0x5d, 0x74, 0xd4, 0x42, 0x28, 0x28, 0x3f, 0x98, 0xb4, 0x73, 0xd1, 0x49, 0x56, 0xe8,
+	0x39, 0x54, 0x71, 0xe8, 0x33, 0xd7, 0xe3, 0x66, 0x79, 0xa9, 0x03, 0x2a, 0x02, 0xda, 0xe1, 0xe8,
+	0x53, 0xa8, 0xc7, 0x78, 0xe0, 0x91, 0x90, 0x84, 0x97, 0x66, 0x65, 0x99, 0xf9, 0x33, 0xac, 0xfd,
+	0x97, 0x02, 0x6c, 0x76, 0x18, 0x23, 0x97, 0xa1, 0x1e, 0x80, 0x8f, 0xa0, 0xa1, 0x87, 0xaf, 0x8a,
+	0xc2, 0x34, 0xce, 0x47, 0xaf, 0x65, 0x2e, 0xcb, 0xc4, 0x33, 0xa4, 0xea, 0xf2, 0x1b, 0x7d, 0x08,
+	0x6b, 0xe3, 0x4d, 0x32, 0xd1, 0x55, 0x4e, 0x37, 0x53, 0xe2, 0x89, 0x48, 0xf8, 0xcc, 0x2d, 0xa5,
+	0x5c, 0x68, 0x7f, 0x05, 0x68, 0x52, 0x8d, 0x3b, 0x04, 0xf6, 0x0c, 0xb5, 0xec, 0x13, 0x28, 0x89,
+	0x8a, 0x20, 0x24, 0x87, 0xc3, 0xc1, 0x05, 0x8e, 0xe5, 0x9f, 0x8a, 0x4e, 0xb2, 0xca, 0xc9, 0x30,
+	0x96, 0xcb, 0xb0, 0x7f, 0x08, 0x4d, 0x51, 0x2c, 0xc4, 0x3f, 0x17, 0x16, 0x95, 0xaf, 0x60, 0x4d,
+	0xc3, 0x31, 0x8a, 0x7e, 0x00, 0x65, 0xa1, 0x50, 0x5a, 0x4a, 0xd6, 0x33, 0x29, 0x02, 0xe3, 0x28,
+	0x26, 0xfa, 0x00, 0x9a, 0x69, 0x05, 0xd1, 0x8e, 0xd2, 0x48, 0x68, 0xb2, 0xb6, 0x51, 0x68, 0x9d,
+	0x89, 0xbc, 0x5b, 0xc9, 0x63, 0x99, 0x9a, 0x86, 0xae, 0xe6, 0xad, 0xbc, 0x66, 0x1f, 0xc2, 0xe6,
+	0x84, 0xc4, 0xd5, 0x9d, 0x63, 0x7f, 0x0c, 0x1b, 0x67, 0x3c, 0xa2, 0xb7, 0x29, 0x74, 0x07, 0xd0,
+	0xca, 0x43, 0xef, 0x20, 0xee, 0x3f, 0x05, 0xa8, 0x8b, 0x44, 0x39, 0x0a, 0x79, 0x3c, 0x7d, 0x19,
+	0xad, 0xe8, 0xf5, 0x71, 0x65, 0xc4, 0xbe, 0x48, 0xcc, 0xe2, 0x2d, 0x2b, 0x23, 0xf6, 0x3b, 0x5c,
+	0x6d, 0x8d, 0x28, 0x55, 0x5b, 0x6f, 0x55, 0x54, 0x25, 0xba, 0xc3, 0xed, 0x6b, 0x58, 0x4b, 0x75,
+	0x39, 0x8f, 0xb8, 0x17, 0xac, 0x9c, 0x10, 0x7a, 0x55, 0x36, 0x6e, 0x5d, 0x95, 0xed, 0x3f, 0x15,
+	0x00, 0x5e, 0x11, 0xc6, 0xa3, 0x58, 0xba, 0xa9, 0x0d, 0xa5, 0x5e, 0x1c, 0x0d, 0xcc, 0xc2, 0x52,
+	0xdd, 0x25, 0x0e, 0xed, 0x82, 0xc1, 0x23, 0xd3, 0x58, 0x8a, 0x36, 0x78, 0xa4, 0x85, 0x40, 0x31,
+	0x17, 0x02, 0xdf, 0x15, 0xa0, 0x31, 0x56, 0x81, 0x51, 0xf4, 0x4c, 0x94, 0x45, 0x1e, 0x67, 0x57,
+	0xf1, 0x56, 0x76, 0xf0, 0xb1, 0x9b, 0x9d, 0x14, 0x83, 0xf6, 0xa0, 0xc2, 0x85, 0xc5, 0x98, 0x69,
+	0x48, 0xf4, 0xce, 0xb4, 0x99, 0xa4, 0x45, 0x9d, 0x04, 0x86, 0xf6, 0xa0, 0x2c, 0xbf, 0xcc, 0xe2,
+	0x32, 0x33, 0x29, 0x9c, 0xed, 0x02, 0x1c, 0x7b, 0xa3, 0x68, 0xc8, 0x17, 0x56, 0x97, 0xa9, 0xf4,
+	0x32, 0x66, 0x14, 0xc5, 0x99, 0x5d, 0x90, 0xfd, 0xcf, 0x02, 0x34, 0x95, 0x84, 0xc3, 0xbe, 0x17,
+	0x5e, 0x62, 0xe1, 0x86, 0x2b, 0x12, 0xaa, 0x28, 0x5e, 0xdf, 0xb7, 0xb2, 0x13, 0xe9, 0xa8, 0x37,
+	0x24, 0xf4, 0x1d, 0x89, 0x9b, 0x59, 0xa4, 0xf5, 0x00, 0x2a, 0xde, 0x22, 0x80, 0xda, 0x50, 0xa3,
+	0x31, 0xbe, 0x26, 0xd1, 0x90, 0x99, 0xa5, 0xf9, 0xf8, 0x14, 0x63, 0x0f, 0x60, 0xbd, 0x43, 0x69,
+	0x30, 0x52, 0x2a, 0x89, 0xe0, 0xd9, 0xcd, 0x97, 0xbd, 0x7b, 0x93, 0x6a, 0xeb, 0xc5, 0x6f, 0x07,
+	0xaa, 0x7e, 0x3c, 0x72, 0xe3, 0xa1, 0x8a, 0xd6, 0x9a, 0x53, 0xf1, 0xe3, 0x91, 0x33, 0x0c, 0xe7,
+	0x46, 0xc9, 0x1f, 0x60, 0x23, 0x27, 0x8e, 0x51, 0xf4, 0x09, 0x54, 0xbb, 0xd2, 0x12, 0xa9, 0xc4,
+	0xed, 0xd9, 0x86, 0x72, 0x52, 0x18, 0x32, 0xa1, 0xea, 0x51, 0x1a, 0x10, 0xec, 0x27, 0x52, 0xd3,
+	0xa5, 0xfd, 0x39, 0x6c, 0x3a, 0x98, 0x45, 0xc1, 0x35, 0x16, 0xed, 0xd1, 0x4b, 0x8f, 0x8a, 0x03,
+	0x21, 0x28, 0x5d, 0x61, 0x4c, 0xa5, 0x1b, 0x6a, 0x8e, 0xfc, 0x9e, 0x57, 0x5d, 0xed, 0x7f, 0x15,
+	0x00, 0x4d, 0xfe, 0xe1, 0x0e, 0xf7, 0x5a, 0x9a, 0x80, 0xc6, 0x4a, 0x09, 0x58, 0xbc, 0x55, 0x02,
+	0xca, 0xe3, 0x50, 0x55, 0x98, 0xe4, 0x71, 0x28, 0xb7, 0xff, 0x6d, 0xc0, 0x9a, 0x83, 0x07, 0x24,
+	0xf4, 0x71, 0xec, 0x0c, 0x45, 0x57, 0xf2, 0x19, 0xac, 0x05, 0x51, 0x78, 0xe9, 0xf2, 0xd8, 0xeb,
+	0x5e, 0x89, 0x26, 0xa3, 0xb0, 0x2c, 0x4d, 0x9a, 0x02, 0x7f, 0x9e, 0xc0, 0xd1, 0xa7, 0x00, 0x38,
+	0xf4, 0xdd, 0xa8, 0xe7, 0xfa, 0xde, 0xe8, 0x16, 0xa5, 0x08, 0x87, 0xfe, 0x69, 0xef, 0x85, 0x37,
+	0x42, 0x3f, 0x03, 0xb8, 0x89, 0xe2, 0x2b, 0x57, 0xd6, 0xd3, 0xe5, 0xc9, 0x59, 0x17, 0x60, 0x79,
+	0x57, 0xa1, 0x1f, 0x43, 0x4d, 0xee, 0xc4, 0xa1, 0x6f, 0x96, 0x96, 0xed, 0xab, 0x0a, 0xe8, 0x51,
+	0xe8, 0xa3, 0x03, 0xd8, 0x18, 0x86, 0xf2, 0x94, 0xa2, 0x5e, 0xf7, 0x38, 0x8e, 0x97, 0xb7, 0xb3,
+	0xeb, 0xe3, 0x1d, 0x1d, 0xb1, 0xc1, 0x7e, 0x06, 0x5b, 0x2f, 0x31, 0xcf, 0x19, 0x70, 0xd1, 0x6d,
+	0x77, 0x04, 0xf7, 0xa6, 0xe1, 0xb2, 0xe4, 0x95, 0x63, 0xb1, 0x48, 0x6c, 0xad, 0x95, 0xb0, 0x3c,
+	0x56, 0xa1, 0x6c, 0x17, 0xb6, 0xdf, 0x52, 0xdf, 0xe3, 0x78, 0x4a, 0xf0, 0x6a, 0x3f, 0x9a, 0x1b,
+	0xcc, 0xaf, 0x60, 0x67, 0xa6, 0x80, 0xd5, 0x55, 0x0d, 0xa0, 0xfa, 0x65, 0x1c, 0xf5, 0x48, 0x80,
+	0xc7, 0x53, 0x61, 0x41, 0x9b, 0x0a, 0x1f, 0x41, 0x23, 0xba, 0x09, 0x5d, 0xaf, 0xdb, 0x8d, 0x86,
+	0x21, 0x4f, 0x92, 0x12, 0xa2, 0x9b, 0xb0, 0xa3, 0x28, 0xe8, 0x29, 0x54, 0x02, 0x99, 0xca, 0x66,
+	0x71, 0x41, 0x51, 0x49, 0x30, 0xa2, 0xf1, 0x10, 0x9d, 0x58, 0x22, 0x71, 0xa1, 0x2b, 0xbe, 0x86,
+	0x56, 0x1e, 0x2a, 0xcf, 0x56, 0xa3, 0xc9, 0x3a, 0xa9, 0x28, 0x9b, 0x99, 0xb8, 0x04, 0xe9, 0x8c,
+	0x21, 0xe2, 0xd7, 0x32, 0x6f, 0xc7, 0xd6, 0x53, 0x2b, 0xfb, 0x33, 0x68, 0x9d, 0xdd, 0x10, 0xde,
+	0xed, 0xa7, 0x5b, 0x54, 0x29, 0x99, 0x3a, 0xfc, 0x3c, 0xeb, 0xc7, 0xb0, 0x39, 0xb1, 0x9f, 0x51,
+	0xf4, 0x04, 0xaa, 0x89, 0xe0, 0xc4, 0xf2, 0x33, 0x54, 0x4b, 0x11, 0x7a, 0x65, 0x34, 0x6e, 0x55,
+	0x19, 0xed, 0x7f, 0x14, 0xa0, 0xf2, 0x42, 0x8a, 0x17, 0x6a, 0x31, 0x1c, 0x13, 0x2f, 0x48, 0x2d,
+	0xa6, 0x56, 0xe8, 0x21, 0xd4, 0xb3, 0x59, 0x4c, 0x79, 0x2a, 0x23, 0x88, 0xd2, 0x9a, 0xea, 0xa7,
+	0x0a, 0xf7, 0x58, 0x19, 0xbd, 0x04, 0x96, 0x56, 0x9b, 0x59, 0xcb, 0xfa, 0xcc, 0x6a, 0xb7, 0x60,
+	0x5d, 0xb8, 0x4c, 0xa9, 0x29, 0x9c, 0x6b, 0xff, 0x0a, 0x36, 0x72, 0x14, 0x46, 0xd1, 0x2e, 0x54,
+	0x95, 0x19, 0x53, 0x17, 0xb6, 0x32, 0x61, 0x0a, 0xe7, 0xa4, 0x00, 0xfb, 0x47, 0xb0, 0xa6, 0x48,
+	0xaf, 0xc3, 0x5e, 0xb4, 0x28, 0x58, 0xfe, 0x67, 0xc0, 0xba, 0x8e, 0x64, 0xf4, 0x8e, 0x56, 0xb2,
+	0xa1, 0x39, 0xf0, 0xc2, 0x61, 0xcf, 0xeb, 0xf2, 0x61, 0x8c, 0xd3, 0x36, 0x20, 0x47, 0x13, 0x3d,
+	0xc2, 0x20, 0xf2, 0x71, 0x90, 0xcc, 0x4d, 0x6a, 0x21, 0xda, 0x0b, 0x25, 0xc1, 0x4d, 0xba, 0x8f,
+	0xb2, 0xda, 0xaa, 0x88, 0x27, 0x92, 0x86, 0x9e, 0xc0, 0x66, 0xdf, 0x8b, 0xfd, 0x1b, 0x2f, 0xc6,
+	0xae, 0xb8, 0xa7, 0xc5, 0xc0, 0x2b, 0x87, 0xc4, 0xba, 0xd3, 0x4a, 0x19, 0x4e, 0x42, 0x17, 0xe0,
+	0x1e, 0x89, 0x07, 0x79, 0x70, 0x55, 0x81, 0x53, 0x86, 0x0e, 0x66, 0x51, 0x8f, 0xe7, 0xc1, 0x6a,
+	0xde, 0x6e, 0xa5, 0x8c, 0x31, 0xf8, 0x11, 0x34, 0xfa, 0x1e, 0x73, 0x2f, 0x3c, 0xce, 0x71, 0x3c,
+	0x32, 0xeb, 0x2a, 0xab, 0xfb, 0x1e, 0x3b, 0x50, 0x14, 0x71, 0x98, 0x84, 0xe9, 0x06, 0xf8, 0x1a,
+	0x07, 0x26, 0xc8, 0xc6, 0xa5, 0x99, 0x10, 0x8f, 0x05, 0xcd, 0x6e, 0x40, 0xfd, 0x15, 0xf6, 0x02,
+	0xde, 0x17, 0x9e, 0xfe, 0xae, 0x08, 0x4d, 0xe5, 0x01, 0x45, 0xbb, 0xa3, 0xfd, 0x11, 0x94, 0x62,
+	0xc6, 0x48, 0x32, 0x9f, 0xcb, 0x6f, 0x74, 0x04, 0xad, 0xc0, 0x63, 0xdc, 0x8d, 0x62, 0x82, 0x43,
+	0x9e, 0x3d, 0x44, 0x2d, 0xbe, 0x50, 0x37, 0xc4, 0x9e, 0xd3, 0x6c, 0x8b, 0xec, 0xff, 0x28, 0x71,
+	0x63, 0xec, 0x75, 0xfb, 0xde, 0xc5, 0x38, 0x78, 0x9b, 0x1e, 0x25, 0x4e, 0x4a, 0x13, 0x96, 0xe1,
+	0xd1, 0x15, 0x0e, 0xdd, 0x6b, 0x2f, 0x20, 0xbe, 0x74, 0x4d, 0xcd, 0x01, 0x49, 0xfa, 0x9d, 0xa0,
+	0xa0, 0x07, 0x50, 0x17, 0x7f, 0xc1, 0x71, 0x1c, 0xc5, 0x89, 0x33, 0x6a, 0x1e, 0x25, 0x47, 0x62,
+	0x8d, 0x7e, 0x01, 0x0d, 0xc1, 0x0c, 0x3c, 0x8e, 0xc3, 0xee, 0xc8, 0xac, 0x2d, 0xbb, 0xad, 0xc0,
+	0xa3, 0xe4, 0x58, 0x81, 0xf5, 0xfc, 0xac, 0xe7, 0xf3, 0xf3, 0x43, 0x58, 0x53, 0x3a, 0x75, 0xfb,
+	0x58, 0xdc, 0x6c, 0xd2, 0x19, 0x35, 0xa7, 0x29, 0x89, 0x87, 0x8a, 0x26, 0x14, 0xff, 0x66, 0x88,
+	0x87, 0xd8, 0xf5, 0x31, 0xe5, 0x7d, 0xb3, 0xa1, 0x9e, 0xe1, 0x24, 0xe9, 0x85, 0xa0, 0xd8, 0x7f,
+	0x17, 0x83, 0x44, 0xe2, 0x2e, 0x46, 0x85, 0xb8, 0x6b, 0x1c, 0xcb, 0x28, 0x51, 0xfe, 0x49, 0x97,
+	0x13, 0xf3, 0x95, 0xb1, 0xca, 0x7c, 0xf5, 0x49, 0x96, 0xdb, 0xc5, 0xc9, 0xb2, 0xa6, 0x07, 0xc7,
+	0x38, 0xc3, 0x77, 0x9f, 0x01, 0x64, 0xaf, 0x34, 0x68, 0x1d, 0xe0, 0x8b, 0xd3, 0xc3, 0xb7, 0x67,
+	0xee, 0xef, 0x4f, 0x9d, 0x37, 0xad, 0xf7, 0xd0, 0x06, 0x34, 0xd4, 0xfa, 0xc0, 0x39, 0xea, 0xbc,
+	0x69, 0x15, 0x76, 0x7f, 0x03, 0xad, 0xc9, 0x0e, 0x1b, 0x6d, 0xc2, 0xda, 0x71, 0xe7, 0xeb, 0xd3,
+	0xb7, 0xe7, 0x6e, 0xe7, 0xec, 0xec, 0xf5, 0xcb, 0x93, 0xd6, 0x7b, 0x1a, 0xe9, 0xd0, 0x39, 0xea,
+	0x9c, 0x1f, 0xb5, 0x0a, 0x68, 0x0b, 0x36, 0x12, 0xd2, 0xdb, 0x93, 0x04, 0x67, 0xec, 0xff, 0xb9,
+	0x0e, 0xc5, 0x77, 0x98, 0xa0, 0x37, 0xaa, 0x70, 0x65, 0xaf, 0x8e, 0xe8, 0x81, 0x56, 0x8f, 0x27,
+	0x1f, 0x2f, 0xad, 0x87, 0xf3, 0x99, 0x8c, 0xa2, 0x13, 0xd8, 0x98, 0x78, 0x19, 0x44, 0xda, 0x86,
+	0xe9, 0x37, 0x46, 0xeb, 0xfb, 0x0b, 0xb8, 0x8c, 0x0a, 0xe5, 0xf2, 0xef, 0x31, 0xba, 0x72, 0x53,
+	0x0f, 0x46, 0xd6, 0xc3, 0xf9, 0x4c, 0x46, 0xd1, 0x2f, 0xa1, 0x3e, 0x7e, 0x0a, 0x41, 0xdb, 0xf9,
+	0x73, 0xa4, 0xef, 0x28, 0xd6, 0xce, 0x4c, 0x3a, 0xa3, 0xe8, 0x00, 0x1a, 0x5a, 0x8f, 0x8f, 0x4c,
+	0x4d, 0x54, 0x6e, 0xd2, 0xb0, 0xee, 0xcf, 0xe1, 0x30, 0x8a, 0x5e, 0xc1, 0x5a, 0xee, 0x01, 0x03,
+	0x69, 0xd3, 0xd3, 0xe4, 0x5b, 0x8a, 0xf5, 0x60, 0x2e, 0x8f, 0x51, 0x74, 0x04, 0x4d, 0xfd, 0x69,
+	0x02, 0xdd, 0xd7, 0xc1, 0xb9, 0xd7, 0x0d, 0xcb, 0x9a, 0xc7, 0x62, 0x14, 0xfd, 0x14, 0xaa, 0xc9,
+	0x74, 0x8b, 0xb4, 0xe6, 0x25, 0x9b, 0xb9, 0xad, 0xef, 0xcd, 0xa0, 0x2a, 0xbf, 0xe4, 0xe7, 0x09,
+	0xdd, 0x2f, 0x53, 0xb3, 0x8a, 0xf5, 0x70, 0x3e, 0x93, 0x51, 0xf4, 0x5b, 0x68, 0x4d, 0x36, 0x9e,
+	0x48, 0x8b, 0x8b, 0x19, 0x3d, 0xac, 0xf5, 0xfe, 0x22, 0x36, 0xa3, 0xe8, 0x1d, 0x6c, 0xcd, 0xe8,
+	0x11, 0xd1, 0xe3, 0x6c, 0xdb, 0xec, 0x1e, 0xd5, 0xfa, 0x60, 0x09, 0x42, 0x99, 0x5e, 0x6f, 0xce,
+	0x74, 0xd3, 0x4f, 0xf4, 0x77, 0x96, 0x35, 0x8f, 0x95, 0xc4, 0x82, 0xde, 0x48, 0xe5, 0x62, 0x61,
+	0xa2, 0x43, 0xb3, 0x1e, 0xcc, 0xe5, 0xa9, 0xc8, 0xd4, 0x1a, 0x0d, 0x3d, 0x32, 0xf3, 0x1d, 0x89,
+	0x75, 0x7f, 0x0e, 0x87, 0x51, 0xf4, 0x39, 0x40, 0xd6, 0x43, 0xa0, 0x9d, 0xc9, 0xd2, 0x95, 0xf4,
+	0x20, 0x96, 0x39, 0x9b, 0xc1, 0x28, 0x7a, 0x0e, 0x95, 0xe4, 0xf2, 0xd3, 0x5e, 0x44, 0xc6, 0x57,
+	0xa4, 0x75, 0x6f, 0x9a, 0xc8, 0xe8, 0x41, 0xe5, 0x5d, 0x49, 0x90, 0x2e, 0x2a, 0xb2, 0xb4, 0x3e,
+	0xff, 0xff, 0x00, 0x7c, 0xf1, 0xb8, 0x23, 0x1c, 0x1a, 0x00, 0x00,
 }